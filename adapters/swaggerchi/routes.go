@@ -0,0 +1,98 @@
+package swaggerchi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+)
+
+// chiMethods are the methods chi registers a route under when it is added with Handle or HandleFunc
+var chiMethods = []string{
+	http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions,
+	http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace,
+}
+
+// Routes lists the routes of router, including mounted subrouters, for swagger.Recorder.Propose,
+// so undocumented routes of chi services can be detected and drafted like gin's router.Routes().
+// Paths are converted to spec templates: "/users/{id:[0-9]+}" becomes "/users/{id}" and a
+// trailing wildcard "/files/*" becomes "/files/{path}". Routes added for every method with
+// Handle are listed as GET.
+//
+// Example:
+//
+//	router.Get("/debug/swagger/proposal", recorder.ProposalHTTPHandler(swagSpec, func() gin.RoutesInfo {
+//	    return swaggerchi.Routes(router)
+//	}).ServeHTTP)
+func Routes(router chi.Routes) gin.RoutesInfo {
+	methods := make(map[string]map[string]bool)
+	_ = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path := chiPathToSpec(route)
+		if methods[path] == nil {
+			methods[path] = make(map[string]bool)
+		}
+		methods[path][method] = true
+		return nil
+	})
+
+	routes := gin.RoutesInfo{}
+	for path, registered := range methods {
+		if registersAll(registered) {
+			registered = map[string]bool{http.MethodGet: true}
+		}
+		for method := range registered {
+			routes = append(routes, gin.RouteInfo{Method: method, Path: path})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// registersAll reports whether a route was added for every method
+func registersAll(registered map[string]bool) bool {
+	for _, method := range chiMethods {
+		if !registered[method] {
+			return false
+		}
+	}
+	return true
+}
+
+// chiPathToSpec converts a chi route pattern into a spec path template, dropping
+// regular expressions from parameters and naming the trailing wildcard "path"
+func chiPathToSpec(route string) string {
+	var b strings.Builder
+	for i := 0; i < len(route); i++ {
+		switch c := route[i]; {
+		case c == '{':
+			// find the matching brace; regular expressions may contain braces themselves
+			depth, end := 1, i+1
+			for ; end < len(route) && depth > 0; end++ {
+				switch route[end] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+			}
+			param := strings.TrimSuffix(route[i+1:end], "}")
+			if name, _, found := strings.Cut(param, ":"); found {
+				param = name
+			}
+			b.WriteString("{" + param + "}")
+			i = end - 1
+		case c == '*' && i == len(route)-1:
+			b.WriteString("{path}")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package swaggerchi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiPathToSpec(t *testing.T) {
+	tests := map[string]string{
+		"/users":                        "/users",
+		"/users/{id}":                   "/users/{id}",
+		"/users/{id:[0-9]+}/orders":     "/users/{id}/orders",
+		"/codes/{code:[A-Z]{3}}":        "/codes/{code}",
+		"/files/*":                      "/files/{path}",
+		"/reports/{year}-{month}/daily": "/reports/{year}-{month}/daily",
+	}
+	for route, want := range tests {
+		assert.Equal(t, want, chiPathToSpec(route), route)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	ok := func(http.ResponseWriter, *http.Request) {}
+	router := chi.NewRouter()
+	router.Get("/health", ok)
+	router.Handle("/metrics", http.HandlerFunc(ok))
+	router.Route("/api", func(r chi.Router) {
+		r.Get("/users/{id:[0-9]+}", ok)
+		r.Delete("/users/{id:[0-9]+}", ok)
+	})
+	files := chi.NewRouter()
+	files.Get("/*", ok)
+	router.Mount("/files", files)
+
+	assert.Equal(t, gin.RoutesInfo{
+		{Method: http.MethodDelete, Path: "/api/users/{id}"},
+		{Method: http.MethodGet, Path: "/api/users/{id}"},
+		{Method: http.MethodGet, Path: "/files/{path}"},
+		{Method: http.MethodGet, Path: "/health"},
+		{Method: http.MethodGet, Path: "/metrics"},
+	}, Routes(router))
+}
+
+func TestProposal(t *testing.T) {
+	recorder, err := swagger.NewRecorder(swagger.RecorderOptions{})
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Get("/api/users/{userId}", func(http.ResponseWriter, *http.Request) {})
+	router.Post("/api/users/{id:[0-9]+}/avatar", func(http.ResponseWriter, *http.Request) {})
+	router.Get("/debug/swagger/proposal", recorder.ProposalHTTPHandler(
+		`{"swagger": "2.0", "info": {"title": "API", "version": "1"}, "paths": {"/api/users/{userId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+		func() gin.RoutesInfo { return Routes(router) },
+	).ServeHTTP)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/swagger/proposal?format=patch", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var patch []swagger.PatchOperation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patch))
+	require.Len(t, patch, 2, "documented routes and the debug route are left out")
+	assert.Equal(t, "/paths/~1api~1users~1{id}~1avatar/post", patch[1].Path)
+}
@@ -0,0 +1,42 @@
+// Package swaggerchi serves Swagger UI and the spec on a chi router.
+//
+//	router := chi.NewRouter()
+//	if err := swaggerchi.Register(router, swagSpec, config); err != nil {
+//	    log.Fatal(err)
+//	}
+package swaggerchi
+
+import (
+	"fmt"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Register serves spec on router as configured; see swagger.NewHandler for the accepted specs.
+// In mock mode it also sets the NotFound handler of router.
+// Invalid configs and route conflicts are returned as errors.
+func Register(router chi.Router, spec interface{}, config *swagger.Config) (err error) {
+	handler, err := swagger.NewHandler(spec, config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register swagger routes: %v", r)
+		}
+	}()
+	for _, route := range handler.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		router.Get(path, handler.ServeHTTP)
+		router.Head(path, handler.ServeHTTP)
+	}
+	if notFound := handler.NotFound(); notFound != nil {
+		router.NotFound(notFound.ServeHTTP)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package swaggerchi
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/OkanUysal/go-swagger/internal/adaptertest"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+		router := chi.NewRouter()
+		router.Get("/api/health", adaptertest.Health)
+		if err := Register(router, spec, config); err != nil {
+			return "", err
+		}
+		server := httptest.NewServer(router)
+		t.Cleanup(server.Close)
+		return server.URL, nil
+	})
+}
@@ -0,0 +1,43 @@
+// Package swaggerecho serves Swagger UI and the spec on an echo server.
+//
+//	e := echo.New()
+//	if err := swaggerecho.Register(e, swagSpec, config); err != nil {
+//	    log.Fatal(err)
+//	}
+package swaggerecho
+
+import (
+	"fmt"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/labstack/echo/v4"
+)
+
+// Register serves spec on e as configured; see swagger.NewHandler for the accepted specs.
+// In mock mode it also adds a RouteNotFound handler for every path.
+// Invalid configs and route conflicts are returned as errors.
+func Register(e *echo.Echo, spec interface{}, config *swagger.Config) (err error) {
+	handler, err := swagger.NewHandler(spec, config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register swagger routes: %v", r)
+		}
+	}()
+	serve := echo.WrapHandler(handler)
+	for _, route := range handler.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		e.GET(path, serve)
+		e.HEAD(path, serve)
+	}
+	if notFound := handler.NotFound(); notFound != nil {
+		e.RouteNotFound("/*", echo.WrapHandler(notFound))
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package swaggerecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/OkanUysal/go-swagger/internal/adaptertest"
+	"github.com/labstack/echo/v4"
+)
+
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+		e := echo.New()
+		e.GET("/api/health", echo.WrapHandler(http.HandlerFunc(adaptertest.Health)))
+		if err := Register(e, spec, config); err != nil {
+			return "", err
+		}
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+		return server.URL, nil
+	})
+}
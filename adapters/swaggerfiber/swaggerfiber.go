@@ -0,0 +1,96 @@
+// Package swaggerfiber serves Swagger UI and the spec on a fiber app.
+//
+//	app := fiber.New()
+//	app.Get("/api/users", listUsers)
+//	if err := swaggerfiber.Register(app, swagSpec, config); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// In mock mode Register adds a handler matching every path, so call it after registering the app routes.
+package swaggerfiber
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+// keepAliveInterval is how often an idle reload stream is written to, which is how fiber
+// notices that the client went away
+const keepAliveInterval = 15 * time.Second
+
+// Register serves spec on app as configured; see swagger.NewHandler for the accepted specs.
+// Invalid configs and route conflicts are returned as errors.
+func Register(app *fiber.App, spec interface{}, config *swagger.Config) (err error) {
+	handler, err := swagger.NewHandler(spec, config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register swagger routes: %v", r)
+		}
+	}()
+	routes := handler.Routes()
+	if watcher, ok := spec.(*swagger.SpecWatcher); ok && len(routes) > 0 {
+		// the adaptor buffers whole responses, so the reload stream is served natively
+		events := []fiber.Handler{}
+		if profile := handler.Config().ActiveProfile(); profile != nil && len(profile.Auth) > 0 {
+			events = append(events, basicauth.New(basicauth.Config{Users: profile.Auth}))
+		}
+		app.Get(handler.Config().UIPath+"/events", append(events, reloadEvents(watcher))...)
+	}
+
+	serve := adaptor.HTTPHandler(handler)
+	for _, route := range routes {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		app.Get(path, serve)
+	}
+	if notFound := handler.NotFound(); notFound != nil {
+		app.Use(adaptor.HTTPHandler(notFound))
+	}
+	return nil
+}
+
+// reloadEvents streams the server-sent "reload" events of watcher, like SpecWatcher.Events
+func reloadEvents(watcher *swagger.SpecWatcher) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+
+		updates, unsubscribe := watcher.Subscribe()
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer unsubscribe()
+			ticker := time.NewTicker(keepAliveInterval)
+			defer ticker.Stop()
+
+			// fasthttp sends the headers with the first bytes of the body
+			fmt.Fprint(w, ":\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+			for {
+				select {
+				case version := <-updates:
+					fmt.Fprintf(w, "event:reload\ndata:%d\n\n", version)
+				case <-ticker.C:
+					fmt.Fprint(w, ":\n\n")
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		})
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package swaggerfiber
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/OkanUysal/go-swagger/internal/adaptertest"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		app.Get("/api/health", adaptor.HTTPHandlerFunc(http.HandlerFunc(adaptertest.Health)))
+		if err := Register(app, spec, config); err != nil {
+			return "", err
+		}
+
+		// a real listener, since app.Test buffers the streamed reload events
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go app.Listener(listener)
+		// reload streams only end on their next write, so shutdown does not wait for them
+		t.Cleanup(func() { _ = app.ShutdownWithTimeout(100 * time.Millisecond) })
+		return "http://" + listener.Addr().String(), nil
+	})
+}
@@ -0,0 +1,23 @@
+// Package swaggergin serves Swagger UI and the spec on a gin router.
+//
+//	router := gin.Default()
+//	if err := swaggergin.Register(router, swagSpec, config); err != nil {
+//	    log.Fatal(err)
+//	}
+package swaggergin
+
+import (
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+)
+
+// Register serves spec on router as configured; see swagger.NewHandler for the accepted specs.
+// In mock mode it replaces the NoRoute handler of router.
+// Invalid configs and route conflicts are returned as errors.
+func Register(router *gin.Engine, spec interface{}, config *swagger.Config) error {
+	handler, err := swagger.NewHandler(spec, config)
+	if err != nil {
+		return err
+	}
+	return swagger.SetupHandler(router, handler)
+}
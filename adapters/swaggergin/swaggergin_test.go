@@ -0,0 +1,24 @@
+package swaggergin
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/OkanUysal/go-swagger/internal/adaptertest"
+	"github.com/gin-gonic/gin"
+)
+
+func TestConformance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+		router := gin.New()
+		router.GET("/api/health", gin.WrapF(adaptertest.Health))
+		if err := Register(router, spec, config); err != nil {
+			return "", err
+		}
+		server := httptest.NewServer(router)
+		t.Cleanup(server.Close)
+		return server.URL, nil
+	})
+}
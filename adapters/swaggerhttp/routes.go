@@ -0,0 +1,98 @@
+package swaggerhttp
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Mux is an http.ServeMux that remembers its patterns, which http.ServeMux cannot list,
+// so its routes can be discovered like gin's router.Routes().
+type Mux struct {
+	*http.ServeMux
+
+	mu       sync.Mutex
+	patterns []string
+}
+
+// NewMux returns an empty Mux
+func NewMux() *Mux {
+	return &Mux{ServeMux: http.NewServeMux()}
+}
+
+// Handle registers handler for pattern, like http.ServeMux.Handle
+func (m *Mux) Handle(pattern string, handler http.Handler) {
+	m.ServeMux.Handle(pattern, handler)
+	m.record(pattern)
+}
+
+// HandleFunc registers handler for pattern, like http.ServeMux.HandleFunc
+func (m *Mux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.ServeMux.HandleFunc(pattern, handler)
+	m.record(pattern)
+}
+
+// record keeps a pattern once ServeMux accepted it
+func (m *Mux) record(pattern string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.patterns = append(m.patterns, pattern)
+}
+
+// Routes lists the registered routes for swagger.Recorder.Propose; see Routes
+func (m *Mux) Routes() gin.RoutesInfo {
+	m.mu.Lock()
+	patterns := append([]string(nil), m.patterns...)
+	m.mu.Unlock()
+	return Routes(patterns...)
+}
+
+// Routes converts Go 1.22 ServeMux patterns ("[METHOD ][HOST]/[PATH]") into routes with spec
+// path templates: "GET /users/{id}" stays "/users/{id}", "{path...}" becomes "{path}", "{$}"
+// is dropped and hosts are ignored. Patterns without a method are listed as GET.
+func Routes(patterns ...string) gin.RoutesInfo {
+	seen := make(map[string]bool)
+	routes := gin.RoutesInfo{}
+	for _, pattern := range patterns {
+		route, ok := patternRoute(pattern)
+		key := route.Method + " " + route.Path
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// patternRoute parses a ServeMux pattern; ok is false when it has no path
+func patternRoute(pattern string) (route gin.RouteInfo, ok bool) {
+	method, rest := http.MethodGet, strings.TrimSpace(pattern)
+	if i := strings.IndexAny(rest, " \t"); i >= 0 {
+		method, rest = rest[:i], strings.TrimSpace(rest[i+1:])
+	}
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return route, false
+	}
+
+	segments := strings.Split(rest[slash:], "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "{$}":
+			segments[i] = ""
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"):
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "...}") + "}"
+		}
+	}
+	return gin.RouteInfo{Method: method, Path: strings.Join(segments, "/")}, true
+}
@@ -0,0 +1,61 @@
+package swaggerhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes(t *testing.T) {
+	assert.Equal(t, gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/"},
+		{Method: http.MethodGet, Path: "/files/{path}"},
+		{Method: http.MethodGet, Path: "/health"},
+		{Method: http.MethodDelete, Path: "/users/{id}"},
+		{Method: http.MethodGet, Path: "/users/{id}"},
+	}, Routes(
+		"GET /users/{id}",
+		"DELETE  /users/{id}",
+		"/health",
+		"GET api.example.com/files/{path...}",
+		"GET /{$}",
+		"GET /users/{id}",
+		"invalid",
+	))
+}
+
+func TestMuxProposal(t *testing.T) {
+	recorder, err := swagger.NewRecorder(swagger.RecorderOptions{})
+	require.NoError(t, err)
+
+	mux := NewMux()
+	mux.HandleFunc("GET /api/users/{userId}", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("PUT /api/users/{id}", func(http.ResponseWriter, *http.Request) {})
+	mux.Handle("GET /debug/swagger/proposal", recorder.ProposalHTTPHandler(
+		`{"swagger": "2.0", "info": {"title": "API", "version": "1"}, "paths": {"/api/users/{userId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+		mux.Routes,
+	))
+	require.NoError(t, Register(mux, `{"swagger": "2.0", "info": {"title": "API", "version": "1"}, "paths": {}}`, quietConfig()))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/swagger/proposal?format=patch", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var patch []swagger.PatchOperation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patch))
+	assert.Equal(t, []string{"/paths/~1api~1users~1{userId}/put"}, patchPaths(patch), "swagger and debug routes are skipped, parameters follow the spec")
+}
+
+func patchPaths(patch []swagger.PatchOperation) []string {
+	paths := make([]string, len(patch))
+	for i, op := range patch {
+		paths[i] = op.Path
+	}
+	return paths
+}
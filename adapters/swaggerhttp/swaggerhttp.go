@@ -0,0 +1,47 @@
+// Package swaggerhttp serves Swagger UI and the spec on a standard library http.ServeMux,
+// and discovers its routes with Mux.
+//
+//	mux := http.NewServeMux()
+//	if err := swaggerhttp.Register(mux, swagSpec, config); err != nil {
+//	    log.Fatal(err)
+//	}
+package swaggerhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// Router is what Register needs of a mux; *http.ServeMux and *Mux implement it
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Register serves spec on mux as configured; see swagger.NewHandler for the accepted specs.
+// In mock mode it also handles "/", which mux only uses when no other pattern matches.
+// Invalid configs and patterns conflicting with registered ones are returned as errors.
+func Register(mux Router, spec interface{}, config *swagger.Config) (err error) {
+	handler, err := swagger.NewHandler(spec, config)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register swagger routes: %v", r)
+		}
+	}()
+	for _, route := range handler.Routes() {
+		pattern := "GET " + route.Path
+		if route.Prefix {
+			pattern += "/"
+		}
+		mux.Handle(pattern, handler)
+	}
+	if notFound := handler.NotFound(); notFound != nil {
+		mux.Handle("/", notFound)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package swaggerhttp
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/OkanUysal/go-swagger/internal/adaptertest"
+)
+
+func TestConformance(t *testing.T) {
+	adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/health", adaptertest.Health)
+		if err := Register(mux, spec, config); err != nil {
+			return "", err
+		}
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return server.URL, nil
+	})
+}
+
+func quietConfig() *swagger.Config {
+	return swagger.NewConfig().WithLogger(log.New(io.Discard, "", 0))
+}
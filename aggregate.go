@@ -0,0 +1,422 @@
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRefreshInterval is how often an Aggregator polls its upstream sources
+const DefaultRefreshInterval = time.Minute
+
+// RemoteSource describes an upstream service whose swagger.json is aggregated
+type RemoteSource struct {
+	// Name identifies the source in the x-sources extension
+	Name string
+
+	// URL is the full URL of the upstream swagger.json
+	URL string
+
+	// PathPrefix is prepended to every upstream path (e.g., "/users-service")
+	PathPrefix string
+}
+
+// SourceStatus reports the health of a single aggregated source
+type SourceStatus struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Healthy bool   `json:"healthy"`
+	// LastSuccess and LastChecked are nil until the source is fetched successfully, or at all
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	LastChecked *time.Time `json:"lastChecked,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// sourceState holds the last good copy of a source and its conditional GET validators
+type sourceState struct {
+	source       RemoteSource
+	spec         map[string]interface{}
+	etag         string
+	lastModified string
+	status       SourceStatus
+}
+
+// Aggregator periodically fetches swagger.json from upstream services and serves the merged result
+type Aggregator struct {
+	config   *Config
+	client   *http.Client
+	interval time.Duration
+
+	mu     sync.RWMutex
+	states []*sourceState
+	merged map[string]interface{}
+	// version counts rebuilds of merged
+	version int64
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// MergeSpecs merges several specs (*SwaggerSpec, swag specs or raw JSON) into one.
+//
+// Info, host and basePath come from the first spec. Paths, definitions,
+// security definitions and tags are combined; the same path and method
+// defined twice, or two different definitions sharing a name, is an error.
+func MergeSpecs(specs ...interface{}) (map[string]interface{}, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no specs to merge")
+	}
+
+	merged := map[string]interface{}{"swagger": "2.0"}
+	for i, spec := range specs {
+		specMap, err := toSpecMap(spec)
+		if err != nil {
+			return nil, fmt.Errorf("spec %d: %w", i, err)
+		}
+		if i == 0 {
+			for _, key := range []string{"swagger", "info", "host", "basePath", "schemes", "consumes", "produces"} {
+				if v, ok := specMap[key]; ok {
+					merged[key] = cloneValue(v)
+				}
+			}
+		}
+		if err := mergeSpecInto(merged, specMap, ""); err != nil {
+			return nil, fmt.Errorf("spec %d: %w", i, err)
+		}
+	}
+	return merged, nil
+}
+
+// mergeSpecInto adds paths, definitions, security definitions and tags of src to dst
+func mergeSpecInto(dst, src map[string]interface{}, pathPrefix string) error {
+	paths, _ := dst["paths"].(map[string]interface{})
+	if paths == nil {
+		paths = make(map[string]interface{})
+	}
+	srcPaths, _ := src["paths"].(map[string]interface{})
+	for path, item := range srcPaths {
+		fullPath := joinPaths(pathPrefix, path)
+		srcItem, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("path %q is not an object", path)
+		}
+		dstItem, _ := paths[fullPath].(map[string]interface{})
+		if dstItem == nil {
+			dstItem = make(map[string]interface{})
+		}
+		for method, op := range srcItem {
+			if _, exists := dstItem[method]; exists {
+				return fmt.Errorf("duplicate operation %s %s", strings.ToUpper(method), fullPath)
+			}
+			dstItem[method] = cloneValue(op)
+		}
+		paths[fullPath] = dstItem
+	}
+	dst["paths"] = paths
+
+	for _, key := range []string{"definitions", "securityDefinitions"} {
+		srcDefs, _ := src[key].(map[string]interface{})
+		if len(srcDefs) == 0 {
+			continue
+		}
+		dstDefs, _ := dst[key].(map[string]interface{})
+		if dstDefs == nil {
+			dstDefs = make(map[string]interface{})
+		}
+		for name, def := range srcDefs {
+			if existing, exists := dstDefs[name]; exists && !reflect.DeepEqual(existing, def) {
+				return fmt.Errorf("conflicting %s entry %q", key, name)
+			}
+			dstDefs[name] = cloneValue(def)
+		}
+		dst[key] = dstDefs
+	}
+
+	if srcTags, _ := src["tags"].([]interface{}); len(srcTags) > 0 {
+		dstTags, _ := dst["tags"].([]interface{})
+		seen := make(map[string]bool)
+		for _, tag := range dstTags {
+			if t, ok := tag.(map[string]interface{}); ok {
+				seen[fmt.Sprint(t["name"])] = true
+			}
+		}
+		for _, tag := range srcTags {
+			t, ok := tag.(map[string]interface{})
+			if !ok || seen[fmt.Sprint(t["name"])] {
+				continue
+			}
+			seen[fmt.Sprint(t["name"])] = true
+			dstTags = append(dstTags, cloneValue(t))
+		}
+		dst["tags"] = dstTags
+	}
+	return nil
+}
+
+// joinPaths joins a path prefix and a spec path without doubling slashes
+func joinPaths(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// NewAggregator creates an Aggregator for the given upstream sources.
+// Title, description and version of the merged spec come from config.
+func NewAggregator(config *Config, sources ...RemoteSource) *Aggregator {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	a := &Aggregator{
+		config:   config,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		interval: DefaultRefreshInterval,
+		stop:     make(chan struct{}),
+	}
+	for _, source := range sources {
+		if source.Name == "" {
+			source.Name = source.URL
+		}
+		a.states = append(a.states, &sourceState{
+			source: source,
+			status: SourceStatus{Name: source.Name, URL: source.URL},
+		})
+	}
+	a.merged = a.merge()
+	return a
+}
+
+// WithInterval sets how often upstream sources are refreshed; DefaultRefreshInterval when not positive
+func (a *Aggregator) WithInterval(interval time.Duration) *Aggregator {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	a.interval = interval
+	return a
+}
+
+// WithHTTPClient sets the HTTP client used to fetch upstream specs
+func (a *Aggregator) WithHTTPClient(client *http.Client) *Aggregator {
+	a.client = client
+	return a
+}
+
+// Start fetches every source once and then keeps refreshing them in the background until Stop is called
+func (a *Aggregator) Start(ctx context.Context) {
+	a.Refresh(ctx)
+
+	go func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				a.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// Stop ends background refreshing started by Start
+func (a *Aggregator) Stop() {
+	a.stopOnce.Do(func() { close(a.stop) })
+}
+
+// Refresh fetches all sources concurrently and rebuilds the merged spec.
+// A source that cannot be fetched keeps serving its last good copy.
+func (a *Aggregator) Refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, state := range a.states {
+		wg.Add(1)
+		go func(state *sourceState) {
+			defer wg.Done()
+			a.fetch(ctx, state)
+		}(state)
+	}
+	wg.Wait()
+
+	merged := a.merge()
+	a.mu.Lock()
+	a.merged = merged
+	a.version++
+	a.mu.Unlock()
+}
+
+// fetch performs a conditional GET for one source and updates its state
+func (a *Aggregator) fetch(ctx context.Context, state *sourceState) {
+	a.mu.RLock()
+	etag, lastModified := state.etag, state.lastModified
+	a.mu.RUnlock()
+
+	spec, newETag, newLastModified, notModified, err := a.get(ctx, state.source.URL, etag, lastModified)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	state.status.LastChecked = &now
+	if err != nil {
+		state.status.Healthy = false
+		state.status.Error = err.Error()
+		return
+	}
+
+	state.status.Healthy = true
+	state.status.Error = ""
+	state.status.LastSuccess = &now
+	if notModified {
+		return
+	}
+	state.spec = spec
+	state.etag = newETag
+	state.lastModified = newLastModified
+}
+
+// get downloads an upstream spec, sending If-None-Match / If-Modified-Since when validators are known
+func (a *Aggregator) get(ctx context.Context, url, etag, lastModified string) (map[string]interface{}, string, string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, lastModified, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", false, fmt.Errorf("failed to read spec: %w", err)
+	}
+	spec, err := decodeSpecMap(data)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	return spec, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), false, nil
+}
+
+// merge builds the combined spec from the last good copy of every source
+func (a *Aggregator) merge() map[string]interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	merged := map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       a.config.Title,
+			"description": a.config.Description,
+			"version":     a.config.Version,
+		},
+		"basePath": "/",
+		"paths":    map[string]interface{}{},
+	}
+	if a.config.Host != "" {
+		merged["host"] = a.config.Host
+	}
+	if len(a.config.Schemes) > 0 {
+		merged["schemes"] = a.config.Schemes
+	}
+
+	statuses := make([]SourceStatus, 0, len(a.states))
+	for _, state := range a.states {
+		if state.spec != nil {
+			// Merge into a scratch copy so a conflicting source leaves no partial result
+			candidate := cloneValue(merged).(map[string]interface{})
+			basePath, _ := state.spec["basePath"].(string)
+			if basePath == "/" {
+				basePath = ""
+			}
+			if err := mergeSpecInto(candidate, state.spec, joinPaths(state.source.PathPrefix, basePath)); err != nil {
+				state.status.Healthy = false
+				state.status.Error = err.Error()
+			} else {
+				merged = candidate
+			}
+		}
+		statuses = append(statuses, state.status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	merged["x-sources"] = statuses
+	return merged
+}
+
+// Spec returns a copy of the current merged spec
+func (a *Aggregator) Spec() map[string]interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// Round-trip through JSON so x-sources becomes plain JSON values
+	data, _ := json.Marshal(a.merged)
+	spec, _ := decodeSpecMap(data)
+	return spec
+}
+
+// specVersion returns the number of times the merged spec was rebuilt
+func (a *Aggregator) specVersion() int64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.version
+}
+
+// Statuses returns the health of every source
+func (a *Aggregator) Statuses() []SourceStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	statuses := make([]SourceStatus, 0, len(a.states))
+	for _, state := range a.states {
+		statuses = append(statuses, state.status)
+	}
+	return statuses
+}
+
+// SetupWithAggregator configures Swagger UI routes serving the merged spec of an Aggregator.
+//
+// Example:
+//
+//	agg := swagger.NewAggregator(config,
+//		swagger.RemoteSource{Name: "users", URL: "http://users:8080/swagger.json", PathPrefix: "/users"},
+//		swagger.RemoteSource{Name: "orders", URL: "http://orders:8080/swagger.json", PathPrefix: "/orders"},
+//	).WithInterval(30 * time.Second)
+//	agg.Start(ctx)
+//	defer agg.Stop()
+//
+//	swagger.SetupWithAggregator(router, agg, config)
+func SetupWithAggregator(router *gin.Engine, agg *Aggregator, config *Config) error {
+	handler, err := NewHandler(agg, config)
+	if err != nil {
+		return err
+	}
+	return SetupHandler(router, handler)
+}
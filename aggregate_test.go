@@ -0,0 +1,140 @@
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usersServiceSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Users", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {"/users": {"get": {"summary": "List users", "responses": {"200": {"description": "OK"}}}}},
+	"definitions": {"User": {"type": "object"}}
+}`
+
+func TestMergeSpecs(t *testing.T) {
+	t.Run("combines paths and definitions", func(t *testing.T) {
+		other := `{"paths": {"/orders": {"get": {"responses": {}}}}, "definitions": {"Order": {"type": "object"}}}`
+
+		merged, err := MergeSpecs(usersServiceSpec, other)
+
+		require.NoError(t, err)
+		assert.Contains(t, merged["paths"], "/users")
+		assert.Contains(t, merged["paths"], "/orders")
+		assert.Contains(t, merged["definitions"], "User")
+		assert.Contains(t, merged["definitions"], "Order")
+		assert.Equal(t, "/api", merged["basePath"])
+	})
+
+	t.Run("rejects duplicate operations", func(t *testing.T) {
+		_, err := MergeSpecs(usersServiceSpec, usersServiceSpec)
+		assert.ErrorContains(t, err, "duplicate operation GET /users")
+	})
+
+	t.Run("accepts a typed spec", func(t *testing.T) {
+		s := New(NewConfig())
+		s.SetPaths(map[string]interface{}{"/ping": map[string]interface{}{"get": map[string]interface{}{}}})
+
+		merged, err := MergeSpecs(s.GetSpec(), usersServiceSpec)
+
+		require.NoError(t, err)
+		assert.Contains(t, merged["paths"], "/ping")
+		assert.Contains(t, merged["paths"], "/users")
+	})
+}
+
+func TestAggregator(t *testing.T) {
+	var requests, notModified int32
+	var down atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(usersServiceSpec))
+	}))
+	defer upstream.Close()
+
+	agg := NewAggregator(NewConfig().WithTitle("Gateway"),
+		RemoteSource{Name: "users", URL: upstream.URL, PathPrefix: "/users-service"},
+	)
+	ctx := context.Background()
+
+	unchecked := agg.Spec()["x-sources"].([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, unchecked, "lastChecked", "sources not checked yet have no timestamps")
+	assert.NotContains(t, unchecked, "lastSuccess")
+
+	agg.Refresh(ctx)
+	spec := agg.Spec()
+	assert.Contains(t, spec["paths"], "/users-service/api/users")
+	assert.Equal(t, "Gateway", spec["info"].(map[string]interface{})["title"])
+
+	t.Run("uses conditional GET", func(t *testing.T) {
+		agg.Refresh(ctx)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+		assert.Contains(t, agg.Spec()["paths"], "/users-service/api/users")
+	})
+
+	t.Run("keeps last good copy when upstream is down", func(t *testing.T) {
+		down.Store(true)
+		agg.Refresh(ctx)
+
+		spec := agg.Spec()
+		assert.Contains(t, spec["paths"], "/users-service/api/users")
+
+		sources := spec["x-sources"].([]interface{})
+		require.Len(t, sources, 1)
+		status := sources[0].(map[string]interface{})
+		assert.Equal(t, "users", status["name"])
+		assert.Equal(t, false, status["healthy"])
+		assert.Equal(t, "unexpected status 503", status["error"])
+		assert.Contains(t, status, "lastChecked")
+		assert.Contains(t, status, "lastSuccess", "the last success is kept")
+	})
+
+	t.Run("serves merged spec with detected host", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		SetupWithAggregator(router, agg, DefaultConfig())
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		req.Host = "gateway.example.com"
+		router.ServeHTTP(w, req)
+
+		var served map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+		assert.Equal(t, "gateway.example.com", served["host"])
+		assert.Contains(t, served, "x-sources")
+	})
+}
+
+func TestAggregatorInvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		agg := NewAggregator(quietConfig()).WithInterval(interval)
+		assert.Equal(t, DefaultRefreshInterval, agg.interval)
+
+		// a non-positive ticker interval would panic in the refresh goroutine
+		ctx, cancel := context.WithCancel(context.Background())
+		agg.Start(ctx)
+		agg.Stop()
+		cancel()
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// runConvert converts a Swagger 2.0 spec to OpenAPI 3.0
+func runConvert(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	to := fs.String("to", "openapi3", "target version (only openapi3 is supported)")
+	format := fs.String("format", "", "output format: json or yaml (default from -o extension, else json)")
+	output := fs.String("o", "", "output file (default stdout)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+	if *to != "openapi3" {
+		return &usageError{msg: fmt.Sprintf("unsupported target %q (want openapi3)", *to)}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	converted, err := swagger.ConvertToOpenAPI3(spec)
+	if err != nil {
+		return err
+	}
+	return writeSpec(converted, *format, *output, stdout)
+}
+
+// runMerge merges several specs into one
+func runMerge(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	format := fs.String("format", "", "output format: json or yaml (default from -o extension, else json)")
+	output := fs.String("o", "", "output file (default stdout)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return &usageError{msg: "expected at least two spec files"}
+	}
+
+	specs := make([]interface{}, 0, len(positional))
+	for _, path := range positional {
+		spec, err := loadSpec(path)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+	merged, err := swagger.MergeSpecs(specs...)
+	if err != nil {
+		return err
+	}
+	return writeSpec(merged, *format, *output, stdout)
+}
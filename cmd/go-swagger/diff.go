@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// runDiff reports changes between two specs and fails on breaking changes
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "output format: markdown or json")
+	failOnBreaking := fs.Bool("fail-on-breaking", true, "exit with status 1 when breaking changes are found")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return &usageError{msg: "expected old and new spec files"}
+	}
+
+	oldSpec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	newSpec, err := loadSpec(positional[1])
+	if err != nil {
+		return err
+	}
+	diff, err := swagger.DiffSpecs(oldSpec, newSpec)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "markdown":
+		fmt.Fprint(stdout, diff.Markdown())
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, string(data))
+	default:
+		return &usageError{msg: fmt.Sprintf("invalid format %q (want markdown or json)", *format)}
+	}
+
+	if *failOnBreaking && diff.HasBreaking() {
+		return &exitError{code: 1}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// runExport builds a spec from a config file, optionally filling paths and definitions from swag output
+func runExport(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	configPath := fs.String("config", "", "config file (JSON, YAML or TOML) with Config fields; SWAGGER_* environment variables override it")
+	docPath := fs.String("doc", "", "swag output (docs/swagger.json) to take paths and definitions from")
+	format := fs.String("format", "", "output format: json or yaml (default from -o extension, else json)")
+	output := fs.String("o", "", "output file (default stdout)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return &usageError{msg: "unexpected arguments"}
+	}
+	if *configPath == "" {
+		return &usageError{msg: "-config is required"}
+	}
+
+	config, err := swagger.LoadConfigFile(*configPath)
+	if err != nil {
+		return err
+	}
+	s := swagger.New(config)
+
+	if *docPath != "" {
+		doc, err := loadSpec(*docPath)
+		if err != nil {
+			return err
+		}
+		docMap := doc.(map[string]interface{})
+		if paths, ok := docMap["paths"].(map[string]interface{}); ok {
+			s.SetPaths(paths)
+		}
+		if definitions, ok := docMap["definitions"].(map[string]interface{}); ok {
+			s.SetDefinitions(definitions)
+		}
+	}
+
+	return writeSpec(s.GetSpec(), *format, *output, stdout)
+}
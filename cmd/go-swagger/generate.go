@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// runGenGo generates a Go client package from a spec
+func runGenGo(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("gen-go", flag.ContinueOnError)
+	packageName := fs.String("package", "client", "package name of the generated file")
+	output := fs.String("o", "", "output file (default stdout)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	code, err := swagger.GenerateGoClient(spec, swagger.GoClientOptions{PackageName: *packageName})
+	if err != nil {
+		return err
+	}
+	return writeOutput(code, *output, stdout)
+}
+
+// runGenTS generates TypeScript types and fetch clients from a spec
+func runGenTS(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("gen-ts", flag.ContinueOnError)
+	typesOnly := fs.Bool("types-only", false, "emit only the types, without fetch clients")
+	output := fs.String("o", "", "output file (default stdout)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file or URL"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	code, err := swagger.GenerateTypeScript(spec, swagger.TypeScriptOptions{SkipClient: *typesOnly})
+	if err != nil {
+		return err
+	}
+	return writeOutput(code, *output, stdout)
+}
+
+// runDocs renders static documentation from a spec: one HTML file, or a directory of Markdown files
+func runDocs(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("docs", flag.ContinueOnError)
+	format := fs.String("format", "html", "output format: html or markdown")
+	output := fs.String("o", "", "output file for html (default stdout), output directory for markdown")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file or URL"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "html":
+		page, err := swagger.RenderHTML(spec)
+		if err != nil {
+			return err
+		}
+		return writeOutput(page, *output, stdout)
+	case "markdown", "md":
+		if *output == "" {
+			return &usageError{msg: "markdown output needs a directory (-o)"}
+		}
+		files, err := swagger.RenderMarkdown(spec)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(*output, 0o755); err != nil {
+			return err
+		}
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(*output, name), data, 0o644); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(stdout, "wrote %d files to %s\n", len(files), *output)
+		return nil
+	default:
+		return &usageError{msg: fmt.Sprintf("unsupported format %q (want html or markdown)", *format)}
+	}
+}
@@ -0,0 +1,251 @@
+// Command go-swagger exports, validates, lints, diffs, converts, merges, serves and mocks Swagger specs
+// and generates clients and documentation from them.
+//
+// Usage:
+//
+//	go-swagger <command> [flags] [args]
+//
+// Run "go-swagger help" for the list of commands.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// command is a go-swagger subcommand
+type command struct {
+	usage       string
+	description string
+	run         func(args []string, stdout, stderr io.Writer) error
+}
+
+// exitError carries a non-zero exit code without printing a usage message
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+// usageError marks invalid command-line usage
+type usageError struct{ msg string }
+
+func (e *usageError) Error() string { return e.msg }
+
+var commands = map[string]command{
+	"export": {
+		usage:       "export -config swagger.yaml [-doc docs/swagger.json] [-format json|yaml] [-o file]",
+		description: "build the spec from a config file and write it as JSON or YAML",
+		run:         runExport,
+	},
+	"validate": {
+		usage:       "validate <spec>",
+		description: "check a spec against the Swagger 2.0 / OpenAPI 3.0 meta-schema and semantic rules",
+		run:         runValidate,
+	},
+	"lint": {
+		usage:       "lint [-config .swagger-lint.yaml] <spec>",
+		description: "check a spec against style rules",
+		run:         runLint,
+	},
+	"diff": {
+		usage:       "diff [-format markdown|json] [-fail-on-breaking=true] <old> <new>",
+		description: "report breaking and non-breaking changes between two specs",
+		run:         runDiff,
+	},
+	"convert": {
+		usage:       "convert -to openapi3 [-format json|yaml] [-o file] <spec>",
+		description: "convert a Swagger 2.0 spec to OpenAPI 3.0",
+		run:         runConvert,
+	},
+	"merge": {
+		usage:       "merge [-format json|yaml] [-o file] <spec> <spec>...",
+		description: "merge several specs into one",
+		run:         runMerge,
+	},
+	"serve": {
+		usage:       "serve [-addr :8080] [-ui-path /swagger] [-json-path /swagger.json] <spec>",
+		description: "serve Swagger UI for a spec file with automatic host detection",
+		run:         runServe,
+	},
+	"mock": {
+		usage:       "mock [-addr :8080] [-latency 0s] [-jitter 0s] [-error-rate 0] [-error-status 500] <spec>",
+		description: "serve mock responses for every operation of a spec file",
+		run:         runMock,
+	},
+	"gen-go": {
+		usage:       "gen-go [-package client] [-o file] <spec>",
+		description: "generate a Go client package from a spec",
+		run:         runGenGo,
+	},
+	"gen-ts": {
+		usage:       "gen-ts [-types-only] [-o file] <spec file or URL>",
+		description: "generate TypeScript types and fetch clients from a spec",
+		run:         runGenTS,
+	},
+	"docs": {
+		usage:       "docs [-format html|markdown] -o file|dir <spec file or URL>",
+		description: "render static HTML or Markdown documentation from a spec",
+		run:         runDocs,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "go-swagger: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	if err := cmd.run(args[1:], stdout, stderr); err != nil {
+		switch e := err.(type) {
+		case *usageError:
+			fmt.Fprintf(stderr, "go-swagger %s: %s\nusage: go-swagger %s\n", args[0], e.msg, cmd.usage)
+			return 2
+		case *exitError:
+			if e.err != nil {
+				fmt.Fprintf(stderr, "go-swagger %s: %v\n", args[0], e.err)
+			}
+			return e.code
+		default:
+			if err == flag.ErrHelp {
+				return 2
+			}
+			fmt.Fprintf(stderr, "go-swagger %s: %v\n", args[0], err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// printUsage lists the available commands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: go-swagger <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+		fmt.Fprintf(w, "  %-10s   go-swagger %s\n", "", commands[name].usage)
+	}
+}
+
+// parseArgs parses flags that may appear before or after positional arguments
+func parseArgs(fs *flag.FlagSet, args []string, stderr io.Writer) ([]string, error) {
+	fs.SetOutput(stderr)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// loadSpec reads a JSON or YAML spec file, or fetches it when path is an http(s) URL
+// such as the swagger.json served by a running application
+func loadSpec(path string) (interface{}, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return fetchSpec(path)
+	}
+	spec, err := swagger.LoadSpecFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// fetchSpec downloads a JSON spec
+func fetchSpec(url string) (interface{}, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec at %s: %w", url, err)
+	}
+	return spec, nil
+}
+
+// outputFormat picks the output format from the -format flag or the output file extension
+func outputFormat(format, output string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(output)) {
+		case ".yaml", ".yml":
+			return "yaml", nil
+		default:
+			return "json", nil
+		}
+	}
+	if format != "json" && format != "yaml" {
+		return "", &usageError{msg: fmt.Sprintf("invalid format %q (want json or yaml)", format)}
+	}
+	return format, nil
+}
+
+// writeSpec writes a spec as JSON or YAML to output, or to stdout when output is empty
+func writeSpec(spec interface{}, format, output string, stdout io.Writer) error {
+	format, err := outputFormat(format, output)
+	if err != nil {
+		return err
+	}
+
+	data, err := swagger.MarshalSpec(spec, format)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		data = append(data, '\n')
+	}
+	return writeOutput(data, output, stdout)
+}
+
+// writeOutput writes data to output, or to stdout when output is empty
+func writeOutput(data []byte, output string, stdout io.Writer) error {
+	if output == "" {
+		_, err := stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(output, data, 0o644)
+}
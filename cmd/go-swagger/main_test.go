@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"paths": {"/users": {"get": {"operationId": "listUsers", "responses": {"200": {"description": "OK"}}}}}
+}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidateCommand(t *testing.T) {
+	code, stdout, _ := runCLI("validate", writeFile(t, "swagger.json", testSpec))
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "is valid")
+
+	code, stdout, stderr := runCLI("validate", writeFile(t, "broken.yaml", "swagger: \"2.0\"\npaths: {}\n"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `/: missing required property "info"`)
+	assert.Contains(t, stderr, "1 problem(s) found")
+}
+
+func TestDiffCommand(t *testing.T) {
+	oldPath := writeFile(t, "old.json", testSpec)
+	newPath := writeFile(t, "new.json", `{"swagger": "2.0", "info": {"title": "API", "version": "2.0"}, "paths": {}}`)
+
+	code, stdout, _ := runCLI("diff", oldPath, newPath)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "endpoint GET /users was removed")
+
+	code, _, _ = runCLI("diff", "-fail-on-breaking=false", oldPath, newPath)
+	assert.Equal(t, 0, code)
+}
+
+func TestConvertAndMergeCommands(t *testing.T) {
+	code, stdout, _ := runCLI("convert", "-to", "openapi3", writeFile(t, "swagger.json", testSpec))
+	require.Equal(t, 0, code)
+	var converted map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &converted))
+	assert.Equal(t, "3.0.3", converted["openapi"])
+
+	other := writeFile(t, "orders.json", `{"paths": {"/orders": {"get": {"responses": {"200": {"description": "OK"}}}}}}`)
+	output := filepath.Join(t.TempDir(), "merged.yaml")
+	code, _, _ = runCLI("merge", "-o", output, writeFile(t, "users.json", testSpec), other)
+	require.Equal(t, 0, code)
+	merged, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "/orders:")
+	assert.Contains(t, string(merged), "/users:")
+}
+
+func TestExportCommand(t *testing.T) {
+	config := writeFile(t, "swagger.yaml", "title: Exported API\nversion: 2.1.0\nbasePath: /api\nbearerAuth: true\n")
+
+	code, stdout, stderr := runCLI("export", "-config", config)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"title": "Exported API"`)
+	assert.Contains(t, stdout, `"basePath": "/api"`)
+	assert.Contains(t, stdout, `"Bearer"`)
+}
+
+func TestExportCommandYAML(t *testing.T) {
+	config := writeFile(t, "swagger.yaml", "title: Exported API\nversion: 2.1.0\nbasePath: /api\nbearerAuth: true\n")
+
+	code, stdout, stderr := runCLI("export", "-config", config, "-format", "yaml")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "basePath: /api\n")
+	assert.Contains(t, stdout, "securityDefinitions:\n")
+	for _, field := range []string{"basepath:", "securitydefinitions:", "contact:", "license:", "schemes:"} {
+		assert.NotContains(t, stdout, field)
+	}
+
+	spec := writeFile(t, "exported.yaml", stdout)
+	code, _, stderr = runCLI("validate", spec)
+	assert.Equal(t, 0, code, stderr)
+}
+
+func TestExportCommandInvalidConfig(t *testing.T) {
+	config := writeFile(t, "swagger.toml", "title = \"API\"\nuiPath = \"docs\"\n")
+
+	code, _, stderr := runCLI("export", "-config", config)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, config+`: uiPath: must start with "/", got "docs"`)
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := runCLI("frobnicate")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown command "frobnicate"`)
+}
+
+func TestGenGoCommand(t *testing.T) {
+	code, stdout, stderr := runCLI("gen-go", "-package", "users", writeFile(t, "swagger.json", testSpec))
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "package users")
+	assert.Contains(t, stdout, "func (c *Client) ListUsers(ctx context.Context) error")
+}
+
+func TestGenTSCommandFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testSpec))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runCLI("gen-ts", server.URL+"/swagger.json")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "export class DefaultClient {")
+	assert.Contains(t, stdout, "listUsers(): Promise<void> {")
+}
+
+func TestDocsCommand(t *testing.T) {
+	spec := writeFile(t, "swagger.json", testSpec)
+
+	code, stdout, stderr := runCLI("docs", spec)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "<!DOCTYPE html>")
+
+	dir := filepath.Join(t.TempDir(), "wiki")
+	code, _, stderr = runCLI("docs", "-format", "markdown", "-o", dir, spec)
+	require.Equal(t, 0, code, stderr)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "default.md"))
+
+	code, _, _ = runCLI("docs", "-format", "markdown", spec)
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+)
+
+// runMock serves mock responses for every operation of a spec file
+func runMock(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("mock", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "listen address")
+	latency := fs.Duration("latency", 0, "delay added to every response")
+	jitter := fs.Duration("jitter", 0, "random extra delay of up to this duration")
+	errorRate := fs.Float64("error-rate", 0, "fraction of requests answered with an injected error")
+	errorStatus := fs.Int("error-status", 500, "status of injected errors")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+
+	router := gin.Default()
+	err = swagger.SetupMock(router, spec, swagger.MockOptions{
+		Latency:     *latency,
+		Jitter:      *jitter,
+		ErrorRate:   *errorRate,
+		ErrorStatus: *errorStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	host := *addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	fmt.Fprintf(stdout, "Mock server: http://%s\n", host)
+	return router.Run(*addr)
+}
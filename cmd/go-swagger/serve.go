@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+)
+
+// runServe serves Swagger UI for a spec file
+func runServe(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "listen address")
+	uiPath := fs.String("ui-path", "/swagger", "Swagger UI path")
+	jsonPath := fs.String("json-path", "/swagger.json", "swagger.json path")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+
+	config := swagger.DefaultConfig().
+		WithUIPath(*uiPath).
+		WithJSONPath(*jsonPath)
+
+	router := gin.Default()
+	if err := swagger.SetupWithSwag(router, spec, config); err != nil {
+		return err
+	}
+
+	host := *addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	fmt.Fprintf(stdout, "Swagger UI: http://%s%s/index.html\n", host, *uiPath)
+	return router.Run(*addr)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// runValidate checks a spec and prints every problem with its JSON pointer
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+
+	err = swagger.ValidateSpec(spec)
+	var problems swagger.ValidationErrors
+	if errors.As(err, &problems) {
+		for _, problem := range problems {
+			fmt.Fprintf(stdout, "%s: %s\n", pointerOrRoot(problem.Pointer), problem.Message)
+		}
+		return &exitError{code: 1, err: fmt.Errorf("%s: %d problem(s) found", positional[0], len(problems))}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s is valid\n", positional[0])
+	return nil
+}
+
+// runLint checks a spec against style rules and fails when an error-severity issue is found
+func runLint(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	configPath := fs.String("config", "", "lint config file (YAML)")
+	positional, err := parseArgs(fs, args, stderr)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &usageError{msg: "expected exactly one spec file"}
+	}
+
+	var config *swagger.LintConfig
+	if *configPath != "" {
+		if config, err = swagger.LoadLintConfig(*configPath); err != nil {
+			return err
+		}
+	}
+
+	spec, err := loadSpec(positional[0])
+	if err != nil {
+		return err
+	}
+	issues, err := swagger.Lint(spec, config)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(stdout, "%-7s %s: %s (%s)\n", issue.Severity, pointerOrRoot(issue.Pointer), issue.Message, issue.Rule)
+	}
+	if issues.HasErrors() {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// pointerOrRoot displays the empty JSON pointer as "/"
+func pointerOrRoot(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
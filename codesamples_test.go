@@ -0,0 +1,131 @@
+package swagger
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// operationSamples returns the x-codeSamples of an operation keyed by label
+func operationSamples(t *testing.T, spec map[string]interface{}, path, method string) map[string]string {
+	t.Helper()
+	op := spec["paths"].(map[string]interface{})[path].(map[string]interface{})[method].(map[string]interface{})
+	entries, ok := op["x-codeSamples"].([]interface{})
+	require.True(t, ok, "%s %s has no x-codeSamples", method, path)
+
+	samples := make(map[string]string)
+	for _, entry := range entries {
+		sample := entry.(map[string]interface{})
+		samples[sample["label"].(string)] = sample["source"].(string)
+	}
+	return samples
+}
+
+func TestAddCodeSamples(t *testing.T) {
+	var original map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(collectionSpec), &original))
+
+	spec, err := AddCodeSamples(original)
+	require.NoError(t, err)
+	assert.NotContains(t, original["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["post"], "x-codeSamples",
+		"the original spec is left untouched")
+
+	create := operationSamples(t, spec, "/pets", "post")
+	assert.Len(t, create, 4)
+	assert.Equal(t, `curl -X POST 'https://api.example.com/v1/pets' \
+  -H 'Authorization: Bearer YOUR_TOKEN' \
+  -H 'Content-Type: application/json' \
+  -d '{"name":"Rex"}'`, create["curl"])
+	assert.Equal(t, `const response = await fetch("https://api.example.com/v1/pets", {
+  method: "POST",
+  headers: {
+    "Authorization": "Bearer YOUR_TOKEN",
+    "Content-Type": "application/json"
+  },
+  body: JSON.stringify({
+    "name": "Rex"
+  })
+});`, create["fetch"])
+	assert.Equal(t, `import requests
+
+response = requests.post(
+    "https://api.example.com/v1/pets",
+    headers={"Authorization": "Bearer YOUR_TOKEN"},
+    json={"name": "Rex"},
+)
+print(response.status_code)`, create["requests"])
+
+	t.Run("go snippets parse", func(t *testing.T) {
+		for _, path := range []string{"/pets", "/pets/{id}", "/health"} {
+			for method := range spec["paths"].(map[string]interface{})[path].(map[string]interface{}) {
+				source := operationSamples(t, spec, path, method)["Go"]
+				_, err := parser.ParseFile(token.NewFileSet(), "sample.go", "package main\nfunc main() {\n"+source+"\n}\n", 0)
+				assert.NoError(t, err, source)
+			}
+		}
+	})
+
+	t.Run("api key in query", func(t *testing.T) {
+		remove := operationSamples(t, spec, "/pets/{id}", "delete")
+		assert.Equal(t, "curl -X DELETE 'https://api.example.com/v1/pets/7?api_key=YOUR_API_KEY'", remove["curl"])
+	})
+
+	t.Run("no security", func(t *testing.T) {
+		health := operationSamples(t, spec, "/health", "get")
+		assert.Equal(t, `const response = await fetch("https://api.example.com/v1/health");`, health["fetch"])
+	})
+}
+
+func TestAddCodeSamplesKeepsExisting(t *testing.T) {
+	spec, err := AddCodeSamples(`{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"securityDefinitions": {"Basic": {"type": "basic"}},
+		"paths": {
+			"/a": {"get": {"x-codeSamples": [{"lang": "Shell", "source": "custom"}], "responses": {"200": {"description": "OK"}}}},
+			"/b": {"get": {"security": [{"Basic": []}], "responses": {"200": {"description": "OK"}}}}
+		}
+	}`)
+	require.NoError(t, err)
+
+	op := spec["paths"].(map[string]interface{})["/a"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Len(t, op["x-codeSamples"], 1)
+
+	basic := operationSamples(t, spec, "/b", "get")
+	assert.Contains(t, basic["curl"], "-u 'YOUR_USERNAME:YOUR_PASSWORD'")
+	assert.Contains(t, basic["Go"], `req.SetBasicAuth("YOUR_USERNAME", "YOUR_PASSWORD")`)
+	assert.Contains(t, basic["requests"], `auth=("YOUR_USERNAME", "YOUR_PASSWORD")`)
+}
+
+func TestCodeSamplesUseDetectedHost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(collectionSpec), &spec))
+
+	router := gin.New()
+	require.NoError(t, SetupWithSwag(router, spec, DefaultConfig().WithCodeSamples(true)))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	req.Host = "localhost:9000"
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+	assert.Contains(t, operationSamples(t, served, "/health", "get")["curl"], "'http://localhost:9000/v1/health'")
+}
+
+func TestPythonLiteral(t *testing.T) {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"b": [1, 2.5, true, null], "a": "it's \"quoted\""}`), &value))
+	assert.Equal(t, `{"a": "it's \"quoted\"", "b": [1, 2.5, True, None]}`, pythonLiteral(value))
+}
@@ -0,0 +1,566 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// postmanSchema is the Postman collection format written by PostmanCollection
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// collectionRequest is an operation prepared for API client exports
+type collectionRequest struct {
+	name        string
+	folder      string
+	description string
+	method      string
+
+	// segments are the path segments with "{name}" parameters kept as is
+	segments []string
+
+	pathParams []collectionParam
+	query      []collectionParam
+	headers    []collectionParam
+	form       []collectionParam
+
+	// body is the indented JSON example body, empty when there is none
+	body string
+
+	// auth is the security definition applied to the request, nil to inherit, empty for none
+	auth *collectionAuth
+}
+
+// collectionParam is a parameter with an example value
+type collectionParam struct {
+	name        string
+	value       string
+	description string
+	required    bool
+}
+
+// collectionAuth is a security definition mapped to client auth settings
+type collectionAuth struct {
+	// kind is "bearer", "apikey", "basic" or "" for no auth
+	kind string
+
+	// name and in locate API keys ("header" or "query")
+	name string
+	in   string
+
+	// variable is the environment variable holding the credential
+	variable string
+}
+
+// collectionSource is a spec flattened for API client exports
+type collectionSource struct {
+	title       string
+	description string
+	baseURL     string
+	auth        *collectionAuth
+	variables   []string
+	requests    []collectionRequest
+}
+
+// PostmanCollection converts a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+// into a Postman v2.1 collection.
+//
+// Tags become folders, example bodies are generated from the schemas, Bearer and API key
+// security definitions become collection and request auth, and the {{baseUrl}} variable is
+// set from the spec's scheme, host and basePath.
+//
+// Example:
+//
+//	collection, err := swagger.PostmanCollection(swagSpec)
+//	data, _ := json.MarshalIndent(collection, "", "  ")
+//	os.WriteFile("api.postman_collection.json", data, 0o644)
+func PostmanCollection(spec interface{}) (map[string]interface{}, error) {
+	source, err := newCollectionSource(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := []interface{}{
+		map[string]interface{}{"key": "baseUrl", "value": source.baseURL, "type": "string"},
+	}
+	for _, name := range source.variables {
+		variables = append(variables, map[string]interface{}{"key": name, "value": "", "type": "string"})
+	}
+
+	var items []interface{}
+	folders := make(map[string][]interface{})
+	var folderNames []string
+	for _, req := range source.requests {
+		item := postmanItem(req)
+		if req.folder == "" {
+			items = append(items, item)
+			continue
+		}
+		if _, ok := folders[req.folder]; !ok {
+			folderNames = append(folderNames, req.folder)
+		}
+		folders[req.folder] = append(folders[req.folder], item)
+	}
+	folderItems := make([]interface{}, 0, len(folderNames)+len(items))
+	for _, name := range folderNames {
+		folderItems = append(folderItems, map[string]interface{}{"name": name, "item": folders[name]})
+	}
+	folderItems = append(folderItems, items...)
+
+	collection := map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":        source.title,
+			"description": source.description,
+			"schema":      postmanSchema,
+		},
+		"item":     folderItems,
+		"variable": variables,
+	}
+	if source.auth != nil {
+		collection["auth"] = postmanAuth(source.auth)
+	}
+	return collection, nil
+}
+
+// postmanItem renders one request
+func postmanItem(req collectionRequest) map[string]interface{} {
+	path := make([]interface{}, len(req.segments))
+	rawPath := make([]string, len(req.segments))
+	for i, segment := range req.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segment = ":" + strings.Trim(segment, "{}")
+		}
+		path[i] = segment
+		rawPath[i] = segment
+	}
+	raw := "{{baseUrl}}/" + strings.Join(rawPath, "/")
+
+	url := map[string]interface{}{
+		"host": []interface{}{"{{baseUrl}}"},
+		"path": path,
+	}
+	if len(req.query) > 0 {
+		query := make([]interface{}, 0, len(req.query))
+		var enabled []string
+		for _, param := range req.query {
+			entry := postmanParam(param)
+			if !param.required {
+				entry["disabled"] = true
+			} else {
+				enabled = append(enabled, param.name+"="+param.value)
+			}
+			query = append(query, entry)
+		}
+		url["query"] = query
+		if len(enabled) > 0 {
+			raw += "?" + strings.Join(enabled, "&")
+		}
+	}
+	if len(req.pathParams) > 0 {
+		variables := make([]interface{}, 0, len(req.pathParams))
+		for _, param := range req.pathParams {
+			variables = append(variables, postmanParam(param))
+		}
+		url["variable"] = variables
+	}
+	url["raw"] = raw
+
+	headers := make([]interface{}, 0, len(req.headers)+1)
+	for _, param := range req.headers {
+		entry := postmanParam(param)
+		if !param.required {
+			entry["disabled"] = true
+		}
+		headers = append(headers, entry)
+	}
+
+	request := map[string]interface{}{
+		"method": req.method,
+		"url":    url,
+	}
+	if req.description != "" {
+		request["description"] = req.description
+	}
+	switch {
+	case req.body != "":
+		headers = append(headers, map[string]interface{}{"key": "Content-Type", "value": "application/json"})
+		request["body"] = map[string]interface{}{
+			"mode":    "raw",
+			"raw":     req.body,
+			"options": map[string]interface{}{"raw": map[string]interface{}{"language": "json"}},
+		}
+	case len(req.form) > 0:
+		fields := make([]interface{}, 0, len(req.form))
+		for _, param := range req.form {
+			entry := postmanParam(param)
+			entry["type"] = "text"
+			fields = append(fields, entry)
+		}
+		request["body"] = map[string]interface{}{"mode": "urlencoded", "urlencoded": fields}
+	}
+	request["header"] = headers
+	if req.auth != nil {
+		request["auth"] = postmanAuth(req.auth)
+	}
+	return map[string]interface{}{"name": req.name, "request": request}
+}
+
+// postmanParam renders a key/value entry
+func postmanParam(param collectionParam) map[string]interface{} {
+	entry := map[string]interface{}{"key": param.name, "value": param.value}
+	if param.description != "" {
+		entry["description"] = param.description
+	}
+	return entry
+}
+
+// postmanAuth renders an auth setting
+func postmanAuth(auth *collectionAuth) map[string]interface{} {
+	variable := "{{" + auth.variable + "}}"
+	switch auth.kind {
+	case "bearer":
+		return map[string]interface{}{
+			"type":   "bearer",
+			"bearer": []interface{}{map[string]interface{}{"key": "token", "value": variable, "type": "string"}},
+		}
+	case "apikey":
+		return map[string]interface{}{
+			"type": "apikey",
+			"apikey": []interface{}{
+				map[string]interface{}{"key": "key", "value": auth.name, "type": "string"},
+				map[string]interface{}{"key": "value", "value": variable, "type": "string"},
+				map[string]interface{}{"key": "in", "value": auth.in, "type": "string"},
+			},
+		}
+	case "basic":
+		return map[string]interface{}{
+			"type": "basic",
+			"basic": []interface{}{
+				map[string]interface{}{"key": "username", "value": "{{" + auth.variable + "Username}}", "type": "string"},
+				map[string]interface{}{"key": "password", "value": "{{" + auth.variable + "Password}}", "type": "string"},
+			},
+		}
+	default:
+		return map[string]interface{}{"type": "noauth"}
+	}
+}
+
+// InsomniaExport converts a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+// into an Insomnia v4 export with the same folders, bodies and auth as PostmanCollection.
+// The base URL and credentials live in the base environment.
+func InsomniaExport(spec interface{}) (map[string]interface{}, error) {
+	source, err := newCollectionSource(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	const workspaceID = "wrk_swagger"
+	environment := map[string]interface{}{"baseUrl": source.baseURL}
+	for _, name := range source.variables {
+		environment[name] = ""
+	}
+	resources := []interface{}{
+		map[string]interface{}{"_id": workspaceID, "_type": "workspace", "name": source.title, "description": source.description},
+		map[string]interface{}{"_id": "env_swagger", "_type": "environment", "parentId": workspaceID, "name": "Base Environment", "data": environment},
+	}
+
+	folderIDs := make(map[string]string)
+	for i, req := range source.requests {
+		parentID := workspaceID
+		if req.folder != "" {
+			id, ok := folderIDs[req.folder]
+			if !ok {
+				id = fmt.Sprintf("fld_%03d", len(folderIDs)+1)
+				folderIDs[req.folder] = id
+				resources = append(resources, map[string]interface{}{"_id": id, "_type": "request_group", "parentId": workspaceID, "name": req.folder})
+			}
+			parentID = id
+		}
+
+		segments := make([]string, len(req.segments))
+		for j, segment := range req.segments {
+			segments[j] = segment
+			for _, param := range req.pathParams {
+				if segment == "{"+param.name+"}" {
+					segments[j] = param.value
+				}
+			}
+		}
+
+		resource := map[string]interface{}{
+			"_id":         fmt.Sprintf("req_%03d", i+1),
+			"_type":       "request",
+			"parentId":    parentID,
+			"name":        req.name,
+			"description": req.description,
+			"method":      req.method,
+			"url":         "{{ _.baseUrl }}/" + strings.Join(segments, "/"),
+			"parameters":  insomniaParams(req.query),
+			"headers":     insomniaParams(req.headers),
+		}
+		switch {
+		case req.body != "":
+			resource["body"] = map[string]interface{}{"mimeType": "application/json", "text": req.body}
+			resource["headers"] = append(resource["headers"].([]interface{}), map[string]interface{}{"name": "Content-Type", "value": "application/json"})
+		case len(req.form) > 0:
+			resource["body"] = map[string]interface{}{"mimeType": "application/x-www-form-urlencoded", "params": insomniaParams(req.form)}
+		default:
+			resource["body"] = map[string]interface{}{}
+		}
+
+		auth := req.auth
+		if auth == nil {
+			auth = source.auth
+		}
+		resource["authentication"] = insomniaAuth(auth)
+		resources = append(resources, resource)
+	}
+
+	return map[string]interface{}{
+		"_type":           "export",
+		"__export_format": 4,
+		"__export_source": "go-swagger",
+		"resources":       resources,
+	}, nil
+}
+
+// insomniaParams renders name/value entries, disabling optional ones
+func insomniaParams(params []collectionParam) []interface{} {
+	out := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		entry := map[string]interface{}{"name": param.name, "value": param.value}
+		if param.description != "" {
+			entry["description"] = param.description
+		}
+		if !param.required {
+			entry["disabled"] = true
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// insomniaAuth renders an auth setting
+func insomniaAuth(auth *collectionAuth) map[string]interface{} {
+	if auth == nil {
+		return map[string]interface{}{}
+	}
+	variable := "{{ _." + auth.variable + " }}"
+	switch auth.kind {
+	case "bearer":
+		return map[string]interface{}{"type": "bearer", "token": variable}
+	case "apikey":
+		addTo := "header"
+		if auth.in == "query" {
+			addTo = "queryParams"
+		}
+		return map[string]interface{}{"type": "apikey", "key": auth.name, "value": variable, "addTo": addTo}
+	case "basic":
+		return map[string]interface{}{
+			"type":     "basic",
+			"username": "{{ _." + auth.variable + "Username }}",
+			"password": "{{ _." + auth.variable + "Password }}",
+		}
+	default:
+		return map[string]interface{}{"type": "none", "disabled": true}
+	}
+}
+
+// newCollectionSource flattens a spec for the exports
+func newCollectionSource(spec interface{}) (*collectionSource, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("collection export supports Swagger 2.0 specs only")
+	}
+	generator, err := NewGenerator(specMap, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := specMap["info"].(map[string]interface{})
+	source := &collectionSource{baseURL: specBaseURL(specMap)}
+	source.title, _ = info["title"].(string)
+	source.description, _ = info["description"].(string)
+	if source.title == "" {
+		source.title = "API"
+	}
+
+	// Each security definition gets variables holding its credentials
+	schemes := securityAuths(specMap)
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		auth := schemes[name]
+		if auth.kind == "basic" {
+			source.variables = appendUnique(source.variables, auth.variable+"Username", auth.variable+"Password")
+		} else {
+			source.variables = appendUnique(source.variables, auth.variable)
+		}
+	}
+	global, _ := specMap["security"].([]interface{})
+	source.auth = requiredAuth(schemes, global)
+
+	for _, op := range specOperations(specMap) {
+		req := collectionRequest{
+			method:   strings.ToUpper(op.Method),
+			segments: strings.Split(strings.Trim(op.Path, "/"), "/"),
+		}
+		req.name, _ = op.Operation["summary"].(string)
+		if req.name == "" {
+			req.name, _ = op.Operation["operationId"].(string)
+		}
+		if req.name == "" {
+			req.name = req.method + " " + op.Path
+		}
+		req.description, _ = op.Operation["description"].(string)
+		if tags := stringSlice(op.Operation["tags"]); len(tags) > 0 {
+			req.folder = tags[0]
+		}
+		if requirements, ok := op.Operation["security"].([]interface{}); ok {
+			if req.auth = requiredAuth(schemes, requirements); req.auth == nil {
+				req.auth = &collectionAuth{}
+			}
+		}
+
+		for _, param := range op.parameters(specMap) {
+			in, _ := param["in"].(string)
+			if in == "body" {
+				data, err := json.MarshalIndent(generator.Generate(param["schema"]), "", "  ")
+				if err != nil {
+					return nil, err
+				}
+				req.body = string(data)
+				continue
+			}
+			p := collectionParam{required: param["required"] == true}
+			p.name, _ = param["name"].(string)
+			p.description, _ = param["description"].(string)
+			if param["type"] != "file" {
+				schema := parameterSchema(param)
+				if example, ok := param["x-example"]; ok {
+					schema["example"] = example
+				}
+				p.value = strings.Join(parameterStrings(generator.Generate(schema), param), ",")
+			}
+			switch in {
+			case "path":
+				req.pathParams = append(req.pathParams, p)
+			case "query":
+				req.query = append(req.query, p)
+			case "header":
+				req.headers = append(req.headers, p)
+			case "formData":
+				req.form = append(req.form, p)
+			}
+		}
+		source.requests = append(source.requests, req)
+	}
+	return source, nil
+}
+
+// securityAuths maps the supported security definitions of a spec to auth settings by name
+func securityAuths(spec map[string]interface{}) map[string]*collectionAuth {
+	schemes := make(map[string]*collectionAuth)
+	definitions, _ := spec["securityDefinitions"].(map[string]interface{})
+	for name, value := range definitions {
+		definition, _ := value.(map[string]interface{})
+		auth := &collectionAuth{variable: goVariable(name)}
+		keyName, _ := definition["name"].(string)
+		switch definition["type"] {
+		case "apiKey":
+			// An API key in the Authorization header is how Swagger 2.0 describes Bearer tokens
+			if strings.EqualFold(keyName, "Authorization") {
+				auth.kind, auth.variable = "bearer", "bearerToken"
+			} else {
+				auth.kind, auth.name = "apikey", keyName
+				auth.in, _ = definition["in"].(string)
+			}
+		case "basic":
+			auth.kind = "basic"
+		case "oauth2":
+			auth.kind, auth.variable = "bearer", "bearerToken"
+		default:
+			continue
+		}
+		schemes[name] = auth
+	}
+	return schemes
+}
+
+// requiredAuth returns the first supported scheme of a security requirement list, nil if there is none
+func requiredAuth(schemes map[string]*collectionAuth, requirements []interface{}) *collectionAuth {
+	for _, requirement := range requirements {
+		names, _ := requirement.(map[string]interface{})
+		for _, name := range sortedKeys(names) {
+			if auth, ok := schemes[name]; ok {
+				return auth
+			}
+		}
+	}
+	return nil
+}
+
+// specBaseURL builds scheme://host/basePath, falling back to http://localhost:8080 when the host is unknown
+func specBaseURL(spec map[string]interface{}) string {
+	basePath, _ := spec["basePath"].(string)
+	return specOrigin(spec) + strings.TrimSuffix(basePath, "/")
+}
+
+// specOrigin builds scheme://host from the first scheme and the host of the spec
+func specOrigin(spec map[string]interface{}) string {
+	host, _ := spec["host"].(string)
+	if host == "" {
+		host = "localhost:8080"
+	}
+	scheme := "http"
+	if schemes := stringSlice(spec["schemes"]); len(schemes) > 0 {
+		scheme = schemes[0]
+	}
+	return scheme + "://" + host
+}
+
+// appendUnique appends values not yet in the slice
+func appendUnique(values []string, more ...string) []string {
+	for _, value := range more {
+		found := false
+		for _, existing := range values {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// ExportPostman exports the Swagger spec as a Postman v2.1 collection JSON string
+func (s *Swagger) ExportPostman() (string, error) {
+	return marshalCollection(PostmanCollection(s.spec))
+}
+
+// ExportInsomnia exports the Swagger spec as an Insomnia v4 export JSON string
+func (s *Swagger) ExportInsomnia() (string, error) {
+	return marshalCollection(InsomniaExport(s.spec))
+}
+
+// marshalCollection indents an export
+func marshalCollection(collection map[string]interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal collection: %w", err)
+	}
+	return string(data), nil
+}
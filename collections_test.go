@@ -0,0 +1,193 @@
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const collectionSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "description": "Pet store", "version": "1.0"},
+	"host": "api.example.com",
+	"schemes": ["https"],
+	"basePath": "/v1",
+	"securityDefinitions": {
+		"Bearer": {"type": "apiKey", "in": "header", "name": "Authorization"},
+		"ApiKey": {"type": "apiKey", "in": "query", "name": "api_key"}
+	},
+	"security": [{"Bearer": []}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"summary": "List pets",
+				"tags": ["pets"],
+				"parameters": [
+					{"name": "limit", "in": "query", "type": "integer", "x-example": 10},
+					{"name": "status", "in": "query", "type": "string", "required": true, "enum": ["available"]}
+				],
+				"responses": {"200": {"description": "OK"}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"tags": ["pets"],
+				"parameters": [
+					{"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+				],
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/pets/{id}": {
+			"delete": {
+				"tags": ["admin"],
+				"security": [{"ApiKey": []}],
+				"parameters": [{"name": "id", "in": "path", "type": "integer", "required": true, "x-example": 7}],
+				"responses": {"204": {"description": "Deleted"}}
+			}
+		},
+		"/health": {
+			"get": {
+				"security": [],
+				"responses": {"200": {"description": "OK"}}
+			}
+		}
+	},
+	"definitions": {
+		"Pet": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {"name": {"type": "string", "example": "Rex"}}
+		}
+	}
+}`
+
+func TestPostmanCollection(t *testing.T) {
+	collection, err := PostmanCollection(collectionSpec)
+	require.NoError(t, err)
+
+	info := collection["info"].(map[string]interface{})
+	assert.Equal(t, "Pets", info["name"])
+	assert.Equal(t, postmanSchema, info["schema"])
+	assert.Equal(t, "bearer", collection["auth"].(map[string]interface{})["type"])
+
+	variables := collection["variable"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"key": "baseUrl", "value": "https://api.example.com/v1", "type": "string"}, variables[0])
+	assert.Len(t, variables, 3, "baseUrl, apiKey and bearerToken")
+
+	items := collection["item"].([]interface{})
+	require.Len(t, items, 3, "pets and admin folders, then the untagged request")
+	pets := items[0].(map[string]interface{})
+	admin := items[1].(map[string]interface{})
+	health := items[2].(map[string]interface{})
+	assert.Equal(t, "admin", admin["name"])
+	assert.Equal(t, "pets", pets["name"])
+	assert.Equal(t, "GET /health", health["name"])
+	assert.Equal(t, "noauth", health["request"].(map[string]interface{})["auth"].(map[string]interface{})["type"])
+
+	t.Run("path variables and api key auth", func(t *testing.T) {
+		item := admin["item"].([]interface{})[0].(map[string]interface{})
+		request := item["request"].(map[string]interface{})
+		url := request["url"].(map[string]interface{})
+		assert.Equal(t, "{{baseUrl}}/pets/:id", url["raw"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"key": "id", "value": "7"}}, url["variable"])
+
+		auth := request["auth"].(map[string]interface{})
+		assert.Equal(t, "apikey", auth["type"])
+		assert.Contains(t, auth["apikey"], map[string]interface{}{"key": "in", "value": "query", "type": "string"})
+	})
+
+	t.Run("query parameters", func(t *testing.T) {
+		item := pets["item"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "List pets", item["name"])
+		url := item["request"].(map[string]interface{})["url"].(map[string]interface{})
+		assert.Equal(t, "{{baseUrl}}/pets?status=available", url["raw"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"key": "limit", "value": "10", "disabled": true},
+			map[string]interface{}{"key": "status", "value": "available"},
+		}, url["query"])
+	})
+
+	t.Run("example body", func(t *testing.T) {
+		item := pets["item"].([]interface{})[1].(map[string]interface{})
+		assert.Equal(t, "createPet", item["name"])
+		request := item["request"].(map[string]interface{})
+		body := request["body"].(map[string]interface{})
+		assert.Equal(t, "raw", body["mode"])
+		assert.JSONEq(t, `{"name": "Rex"}`, body["raw"].(string))
+		assert.NotContains(t, request, "auth", "inherits the collection auth")
+	})
+}
+
+func TestInsomniaExport(t *testing.T) {
+	export, err := InsomniaExport(collectionSpec)
+	require.NoError(t, err)
+	assert.Equal(t, 4, export["__export_format"])
+
+	resources := export["resources"].([]interface{})
+	byType := make(map[string][]map[string]interface{})
+	for _, resource := range resources {
+		r := resource.(map[string]interface{})
+		byType[r["_type"].(string)] = append(byType[r["_type"].(string)], r)
+	}
+	require.Len(t, byType["workspace"], 1)
+	require.Len(t, byType["request_group"], 2)
+	require.Len(t, byType["request"], 4)
+
+	environment := byType["environment"][0]["data"].(map[string]interface{})
+	assert.Equal(t, "https://api.example.com/v1", environment["baseUrl"])
+
+	remove := byType["request"][3]
+	assert.Equal(t, "DELETE", remove["method"])
+	assert.Equal(t, "{{ _.baseUrl }}/pets/7", remove["url"])
+	assert.Equal(t, map[string]interface{}{"type": "apikey", "key": "api_key", "value": "{{ _.apiKey }}", "addTo": "queryParams"}, remove["authentication"])
+
+	health := byType["request"][0]
+	assert.Equal(t, map[string]interface{}{"type": "none", "disabled": true}, health["authentication"])
+
+	create := byType["request"][2]
+	assert.Equal(t, "createPet", create["name"])
+	assert.Equal(t, map[string]interface{}{"type": "bearer", "token": "{{ _.bearerToken }}"}, create["authentication"])
+}
+
+func TestCollectionRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(collectionSpec), &spec))
+
+	router := gin.New()
+	config := DefaultConfig().WithPostmanPath("/swagger.postman.json").WithInsomniaPath("/swagger.insomnia.json")
+	require.NoError(t, SetupWithSwag(router, spec, config))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/swagger.postman.json", nil)
+	req.Host = "localhost:9000"
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var collection map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection))
+	variable := collection["variable"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "http://localhost:9000/v1", variable["value"], "baseUrl uses the detected host")
+	assert.Equal(t, "api.example.com", spec["host"], "the original spec is left untouched")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.insomnia.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestExportPostman(t *testing.T) {
+	sw := New(NewConfig().WithTitle("Store"))
+	out, err := sw.ExportPostman()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"name": "Store"`)
+
+	out, err = sw.ExportInsomnia()
+	require.NoError(t, err)
+	assert.Contains(t, out, `"_type": "workspace"`)
+}
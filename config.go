@@ -1,5 +1,10 @@
 package swagger
 
+import (
+	"log"
+	"strings"
+)
+
 // Config holds the Swagger configuration
 type Config struct {
 	// Title is the API title
@@ -27,10 +32,14 @@ type Config struct {
 	AutoDetectHost bool
 
 	// Enabled controls whether Swagger UI is enabled
-	// Set to false in production
+	// Set to false in production. When left at true and not set with WithEnabled,
+	// the active profile decides.
 	// Default: true
 	Enabled bool
 
+	// enabledSet records that Enabled was set with WithEnabled, a config file or SWAGGER_ENABLED
+	enabledSet bool
+
 	// UIPath is the path to serve Swagger UI
 	// Default: "/swagger"
 	UIPath string
@@ -39,10 +48,44 @@ type Config struct {
 	// Default: "/swagger/doc.json"
 	JSONPath string
 
+	// PostmanPath serves the spec as a Postman v2.1 collection when set
+	// (e.g., "/swagger.postman.json")
+	// Default: "" (disabled)
+	PostmanPath string
+
+	// InsomniaPath serves the spec as an Insomnia v4 export when set
+	// Default: "" (disabled)
+	InsomniaPath string
+
+	// CodeSamples adds x-codeSamples (curl, Go, JavaScript, Python) to every operation
+	// of the served spec, using the detected host and scheme of each request
+	// Default: false
+	CodeSamples bool
+
+	// Recorder merges examples recorded from live traffic into the served spec
+	// Default: nil (disabled)
+	Recorder *Recorder
+
 	// BearerAuth enables JWT Bearer authentication in Swagger
 	// Default: false
 	BearerAuth bool
 
+	// FailOnInvalidSpec makes SetupWithSwag validate the spec and return
+	// an error instead of serving it when it is invalid
+	// Default: false
+	FailOnInvalidSpec bool
+
+	// Environment selects the active profile by name (e.g., "staging")
+	// Default: "" (SWAGGER_ENV)
+	Environment string
+
+	// Profiles defines custom profiles or replaces the built-in development, staging
+	// and production ones, keyed by name
+	Profiles map[string]Profile
+
+	// Logger receives the startup line listing the served routes (default: the standard logger)
+	Logger *log.Logger
+
 	// Contact information
 	ContactName  string
 	ContactEmail string
@@ -70,12 +113,46 @@ func NewConfig() *Config {
 
 // DefaultConfig returns a Config with sensible defaults (alias for NewConfig)
 func DefaultConfig() *Config {
-	return &Config{
-		AutoDetectHost: true, // Auto-detect by default for Railway
-		Enabled:        true,
-		UIPath:         "/swagger",
-		JSONPath:       "/swagger.json",
+	return NewConfig()
+}
+
+// Validate normalises the config and checks it, returning every problem as ConfigErrors.
+// Empty Title, Version, UIPath and JSONPath are set to their NewConfig defaults and
+// a trailing "/" is trimmed from UIPath. Setup and friends call it before registering routes.
+func (c *Config) Validate() error {
+	c.normalize()
+	if errs := validateConfig(c); len(errs) > 0 {
+		return ConfigErrors(errs)
+	}
+	return nil
+}
+
+// normalize fills empty options with their defaults
+func (c *Config) normalize() {
+	defaults := NewConfig()
+	if c.Title == "" {
+		c.Title = defaults.Title
+	}
+	if c.Version == "" {
+		c.Version = defaults.Version
+	}
+	if c.UIPath == "" {
+		c.UIPath = defaults.UIPath
+	}
+	if c.JSONPath == "" {
+		c.JSONPath = defaults.JSONPath
+	}
+	if len(c.UIPath) > 1 {
+		c.UIPath = strings.TrimSuffix(c.UIPath, "/")
+	}
+}
+
+// logger returns the configured Logger or the standard logger
+func (c *Config) logger() *log.Logger {
+	if c.Logger != nil {
+		return c.Logger
 	}
+	return log.Default()
 }
 
 // WithTitle sets the API title
@@ -114,6 +191,12 @@ func (c *Config) WithBearerAuth(enabled bool) *Config {
 	return c
 }
 
+// WithFailOnInvalidSpec sets whether an invalid spec fails SetupWithSwag
+func (c *Config) WithFailOnInvalidSpec(enabled bool) *Config {
+	c.FailOnInvalidSpec = enabled
+	return c
+}
+
 // WithSchemes sets the API schemes
 func (c *Config) WithSchemes(schemes []string) *Config {
 	c.Schemes = schemes
@@ -138,6 +221,7 @@ func (c *Config) WithLicense(name, url string) *Config {
 // WithEnabled sets whether Swagger is enabled
 func (c *Config) WithEnabled(enabled bool) *Config {
 	c.Enabled = enabled
+	c.enabledSet = true
 	return c
 }
 
@@ -158,3 +242,48 @@ func (c *Config) WithJSONPath(path string) *Config {
 	c.JSONPath = path
 	return c
 }
+
+// WithCodeSamples enables/disables x-codeSamples in the served spec
+func (c *Config) WithCodeSamples(enabled bool) *Config {
+	c.CodeSamples = enabled
+	return c
+}
+
+// WithRecorder serves the examples recorded by a Recorder in the spec
+func (c *Config) WithRecorder(recorder *Recorder) *Config {
+	c.Recorder = recorder
+	return c
+}
+
+// WithEnvironment selects the active profile
+func (c *Config) WithEnvironment(name string) *Config {
+	c.Environment = name
+	return c
+}
+
+// WithProfile defines a profile, replacing a built-in one of the same name
+func (c *Config) WithProfile(profile Profile) *Config {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[strings.ToLower(profile.Name)] = profile
+	return c
+}
+
+// WithLogger sets the logger for the startup line
+func (c *Config) WithLogger(logger *log.Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
+// WithPostmanPath sets the Postman collection path
+func (c *Config) WithPostmanPath(path string) *Config {
+	c.PostmanPath = path
+	return c
+}
+
+// WithInsomniaPath sets the Insomnia export path
+func (c *Config) WithInsomniaPath(path string) *Config {
+	c.InsomniaPath = path
+	return c
+}
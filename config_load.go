@@ -0,0 +1,344 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigError describes one invalid configuration value
+type ConfigError struct {
+	// Source is where the value came from: a file path, an environment variable or "" for code
+	Source string `json:"source,omitempty"`
+
+	// Field is the option name as written in config files (e.g., "uiPath")
+	Field string `json:"field"`
+
+	// Message describes what is wrong
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e ConfigError) Error() string {
+	switch {
+	case e.Source == "":
+		return e.Field + ": " + e.Message
+	case strings.HasPrefix(e.Source, envPrefix):
+		// the variable name already identifies the option
+		return e.Source + ": " + e.Message
+	default:
+		return e.Source + ": " + e.Field + ": " + e.Message
+	}
+}
+
+// ConfigErrors is a list of configuration problems returned as a single error
+type ConfigErrors []ConfigError
+
+// Error implements the error interface
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+const (
+	// envPrefix prefixes every environment variable read by LoadConfig
+	envPrefix = "SWAGGER_"
+
+	// configFileEnv names the config file LoadConfig reads
+	configFileEnv = "SWAGGER_CONFIG"
+)
+
+// configField maps a Config option to its config file key and environment variable
+type configField struct {
+	key    string
+	env    string
+	target func(c *Config) interface{}
+}
+
+// configFields lists every option that can be loaded from files and the environment
+var configFields = []configField{
+	{"title", "SWAGGER_TITLE", func(c *Config) interface{} { return &c.Title }},
+	{"description", "SWAGGER_DESCRIPTION", func(c *Config) interface{} { return &c.Description }},
+	{"version", "SWAGGER_VERSION", func(c *Config) interface{} { return &c.Version }},
+	{"host", "SWAGGER_HOST", func(c *Config) interface{} { return &c.Host }},
+	{"basePath", "SWAGGER_BASE_PATH", func(c *Config) interface{} { return &c.BasePath }},
+	{"schemes", "SWAGGER_SCHEMES", func(c *Config) interface{} { return &c.Schemes }},
+	{"autoDetectHost", "SWAGGER_AUTO_DETECT_HOST", func(c *Config) interface{} { return &c.AutoDetectHost }},
+	{"enabled", "SWAGGER_ENABLED", func(c *Config) interface{} { return &c.Enabled }},
+	{"uiPath", "SWAGGER_UI_PATH", func(c *Config) interface{} { return &c.UIPath }},
+	{"jsonPath", "SWAGGER_JSON_PATH", func(c *Config) interface{} { return &c.JSONPath }},
+	{"postmanPath", "SWAGGER_POSTMAN_PATH", func(c *Config) interface{} { return &c.PostmanPath }},
+	{"insomniaPath", "SWAGGER_INSOMNIA_PATH", func(c *Config) interface{} { return &c.InsomniaPath }},
+	{"codeSamples", "SWAGGER_CODE_SAMPLES", func(c *Config) interface{} { return &c.CodeSamples }},
+	{"bearerAuth", "SWAGGER_BEARER_AUTH", func(c *Config) interface{} { return &c.BearerAuth }},
+	{"failOnInvalidSpec", "SWAGGER_FAIL_ON_INVALID_SPEC", func(c *Config) interface{} { return &c.FailOnInvalidSpec }},
+	{"contactName", "SWAGGER_CONTACT_NAME", func(c *Config) interface{} { return &c.ContactName }},
+	{"contactEmail", "SWAGGER_CONTACT_EMAIL", func(c *Config) interface{} { return &c.ContactEmail }},
+	{"contactURL", "SWAGGER_CONTACT_URL", func(c *Config) interface{} { return &c.ContactURL }},
+	{"licenseName", "SWAGGER_LICENSE_NAME", func(c *Config) interface{} { return &c.LicenseName }},
+	{"licenseURL", "SWAGGER_LICENSE_URL", func(c *Config) interface{} { return &c.LicenseURL }},
+	{"environment", "SWAGGER_ENV", func(c *Config) interface{} { return &c.Environment }},
+}
+
+// LoadConfig builds a Config from, in increasing precedence:
+//  1. the NewConfig defaults
+//  2. the YAML, JSON or TOML file named by SWAGGER_CONFIG, if set
+//  3. SWAGGER_* environment variables (SWAGGER_ENABLED, SWAGGER_UI_PATH, SWAGGER_HOST, SWAGGER_BEARER_AUTH...)
+//
+// Invalid values are returned together as ConfigErrors, each naming the file or variable it came from.
+//
+// Example:
+//
+//	config, err := swagger.LoadConfig()
+//	if err != nil {
+//	    log.Fatal(err) // e.g. "SWAGGER_UI_PATH: must start with "/", got "docs""
+//	}
+//	swagger.Setup(router, config)
+func LoadConfig() (*Config, error) {
+	return LoadConfigFile(os.Getenv(configFileEnv))
+}
+
+// LoadConfigFile is like LoadConfig but reads the given file instead of SWAGGER_CONFIG.
+// The format is chosen by extension: .json, .toml, otherwise YAML. An empty path reads no file.
+// File keys are the Config field names in any case, with optional "_" or "-" separators
+// (uiPath, ui_path and UIPath are the same option).
+func LoadConfigFile(path string) (*Config, error) {
+	config := NewConfig()
+	sources := make(map[string]string)
+	var errs ConfigErrors
+
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, applyConfigFile(config, values, path, sources)...)
+	}
+	errs = append(errs, applyConfigEnv(config, sources)...)
+
+	config.normalize()
+	for _, problem := range validateConfig(config) {
+		problem.Source = sources[problem.Field]
+		errs = append(errs, problem)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return config, nil
+}
+
+// readConfigFile decodes a YAML, JSON or TOML config file into a map
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// applyConfigFile sets the options found in a decoded config file
+func applyConfigFile(config *Config, values map[string]interface{}, path string, sources map[string]string) []ConfigError {
+	fields := make(map[string]configField, len(configFields))
+	for _, field := range configFields {
+		fields[normalizeConfigKey(field.key)] = field
+	}
+
+	var errs []ConfigError
+	for _, key := range sortedKeys(values) {
+		if normalizeConfigKey(key) == "profiles" {
+			if err := setConfigProfiles(config, values[key]); err != nil {
+				errs = append(errs, ConfigError{Source: path, Field: key, Message: err.Error()})
+				continue
+			}
+			for name := range config.Profiles {
+				sources["profiles."+name] = path
+			}
+			continue
+		}
+		field, ok := fields[normalizeConfigKey(key)]
+		if !ok {
+			errs = append(errs, ConfigError{Source: path, Field: key, Message: "unknown option"})
+			continue
+		}
+		if err := setConfigValue(field.target(config), values[key]); err != nil {
+			errs = append(errs, ConfigError{Source: path, Field: key, Message: err.Error()})
+			continue
+		}
+		config.enabledSet = config.enabledSet || field.key == "enabled"
+		sources[field.key] = path
+	}
+	return errs
+}
+
+// applyConfigEnv sets the options found in SWAGGER_* environment variables
+func applyConfigEnv(config *Config, sources map[string]string) []ConfigError {
+	var errs []ConfigError
+	for _, field := range configFields {
+		value, ok := os.LookupEnv(field.env)
+		if !ok {
+			continue
+		}
+		if err := setConfigString(field.target(config), value); err != nil {
+			errs = append(errs, ConfigError{Source: field.env, Field: field.key, Message: err.Error()})
+			continue
+		}
+		config.enabledSet = config.enabledSet || field.key == "enabled"
+		sources[field.key] = field.env
+	}
+	return errs
+}
+
+// setConfigValue assigns a decoded file value to a Config field
+func setConfigValue(target interface{}, value interface{}) error {
+	switch target := target.(type) {
+	case *string:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string, got %v (quote the value)", value)
+		}
+		*target = s
+	case *bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("must be true or false, got %v", value)
+		}
+		*target = b
+	case *[]string:
+		switch value := value.(type) {
+		case string:
+			return setConfigString(target, value)
+		case []interface{}:
+			list := make([]string, len(value))
+			for i, item := range value {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("item %d must be a string, got %v", i, item)
+				}
+				list[i] = s
+			}
+			*target = list
+		default:
+			return fmt.Errorf("must be a list of strings, got %v", value)
+		}
+	}
+	return nil
+}
+
+// setConfigProfiles decodes the "profiles" section of a config file, keyed by profile name
+func setConfigProfiles(config *Config, value interface{}) error {
+	if _, ok := value.(map[string]interface{}); !ok {
+		return fmt.Errorf("must be a map of profile names to profiles, got %v", value)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var profiles map[string]Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return err
+	}
+	for name, profile := range profiles {
+		if profile.Name == "" {
+			profile.Name = name
+		}
+		config.WithProfile(profile)
+	}
+	return nil
+}
+
+// setConfigString assigns an environment value to a Config field;
+// lists are comma-separated
+func setConfigString(target interface{}, value string) error {
+	switch target := target.(type) {
+	case *string:
+		*target = value
+	case *bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+		*target = b
+	case *[]string:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*target = list
+	}
+	return nil
+}
+
+// normalizeConfigKey folds case and drops "_" and "-" so every spelling of a key matches
+func normalizeConfigKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// validateConfig checks paths, schemes and route collisions.
+// Problems are keyed by the config file name of the offending option.
+func validateConfig(config *Config) []ConfigError {
+	var errs []ConfigError
+
+	paths := []struct {
+		field string
+		value string
+	}{
+		{"basePath", config.BasePath},
+		{"uiPath", config.UIPath},
+		{"jsonPath", config.JSONPath},
+		{"postmanPath", config.PostmanPath},
+		{"insomniaPath", config.InsomniaPath},
+	}
+	for _, path := range paths {
+		if path.value != "" && !strings.HasPrefix(path.value, "/") {
+			errs = append(errs, ConfigError{Field: path.field, Message: fmt.Sprintf("must start with \"/\", got %q", path.value)})
+		}
+	}
+
+	if config.UIPath == "/" {
+		errs = append(errs, ConfigError{Field: "uiPath", Message: `must not be "/", the UI routes under it would shadow every other route`})
+	}
+
+	if strings.Contains(config.Host, "://") || strings.Contains(config.Host, "/") {
+		errs = append(errs, ConfigError{Field: "host", Message: fmt.Sprintf("must be a host[:port] without scheme or path, got %q", config.Host)})
+	}
+
+	for _, scheme := range config.Schemes {
+		if scheme != "http" && scheme != "https" {
+			errs = append(errs, ConfigError{Field: "schemes", Message: fmt.Sprintf("must be http or https, got %q", scheme)})
+		}
+	}
+
+	// the UI is served at UIPath/*any, so nothing else may live at or below it
+	if ui := strings.TrimSuffix(config.UIPath, "/"); ui != "" {
+		for _, path := range paths[2:] {
+			if path.value == ui || strings.HasPrefix(path.value, ui+"/") {
+				errs = append(errs, ConfigError{Field: path.field, Message: fmt.Sprintf("%q collides with the UI routes under uiPath %q", path.value, config.UIPath)})
+			}
+		}
+	}
+	return append(errs, validateProfiles(config)...)
+}
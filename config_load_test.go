@@ -0,0 +1,141 @@
+package swagger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	files := map[string]string{
+		"swagger.yaml": "title: Users API\nversion: 2.1.0\nui_path: /docs\nschemes: [https]\nbearerAuth: true\n",
+		"swagger.json": `{"Title": "Users API", "version": "2.1.0", "UIPath": "/docs", "schemes": ["https"], "bearer-auth": true}`,
+		"swagger.toml": "title = \"Users API\"\nversion = \"2.1.0\"\nuiPath = \"/docs\"\nschemes = [\"https\"]\nbearerAuth = true\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := LoadConfigFile(writeConfigFile(t, name, content))
+			require.NoError(t, err)
+			assert.Equal(t, "Users API", config.Title)
+			assert.Equal(t, "2.1.0", config.Version)
+			assert.Equal(t, "/docs", config.UIPath)
+			assert.Equal(t, []string{"https"}, config.Schemes)
+			assert.True(t, config.BearerAuth)
+			assert.Equal(t, "/swagger.json", config.JSONPath, "unset options keep the defaults")
+			assert.Equal(t, "API Documentation", config.Description)
+		})
+	}
+}
+
+func TestLoadConfigEnvOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, "swagger.yaml", "title: From File\nenabled: true\nhost: file.example.com\n")
+	t.Setenv("SWAGGER_CONFIG", path)
+	t.Setenv("SWAGGER_ENABLED", "false")
+	t.Setenv("SWAGGER_HOST", "api.example.com")
+	t.Setenv("SWAGGER_SCHEMES", "https, http")
+
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "From File", config.Title)
+	assert.False(t, config.Enabled)
+	assert.Equal(t, "api.example.com", config.Host)
+	assert.Equal(t, []string{"https", "http"}, config.Schemes)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	path := writeConfigFile(t, "swagger.yaml", "version: 1.0\nuiPath: docs\nschemes: [ftp]\ntheme: dark\n")
+	t.Setenv("SWAGGER_BEARER_AUTH", "yes please")
+	t.Setenv("SWAGGER_JSON_PATH", "/swagger/doc.json")
+	t.Setenv("SWAGGER_UI_PATH", "/swagger")
+
+	_, err := LoadConfigFile(path)
+	require.Error(t, err)
+
+	var problems ConfigErrors
+	require.True(t, errors.As(err, &problems))
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Error()
+	}
+	assert.Equal(t, []string{
+		path + ": theme: unknown option",
+		path + ": version: must be a string, got 1 (quote the value)",
+		`SWAGGER_BEARER_AUTH: must be true or false, got "yes please"`,
+		path + `: schemes: must be http or https, got "ftp"`,
+		`SWAGGER_JSON_PATH: "/swagger/doc.json" collides with the UI routes under uiPath "/swagger"`,
+	}, messages)
+}
+
+func TestLoadConfigProfiles(t *testing.T) {
+	path := writeConfigFile(t, "swagger.yaml", `environment: qa
+profiles:
+  qa:
+    enabled: true
+    tryItOut: true
+    audiences: [internal, partner]
+    servers: ["https://qa.example.com"]
+  edge:
+    defaultScheme: ftp
+`)
+	_, err := LoadConfigFile(path)
+	assert.EqualError(t, err, path+`: profiles.edge: defaultScheme must be http or https, got "ftp"`)
+
+	path = writeConfigFile(t, "swagger.yaml", "environment: qa\nprofiles:\n  qa:\n    enabled: true\n    audiences: [internal]\n")
+	config, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "qa", Enabled: Bool(true), Audiences: []string{"internal"}}, config.ActiveProfile())
+
+	path = writeConfigFile(t, "swagger.yaml", "environment: qa\nprofiles:\n  qa:\n    audiences: [internal]\n")
+	config, err = LoadConfigFile(path)
+	require.NoError(t, err)
+	assert.Nil(t, config.ActiveProfile().Enabled, "a profile without enabled keeps Config.Enabled")
+
+	for name, env := range map[string]map[string]string{
+		"enabled in the file wins over the profile": {"SWAGGER_ENV": "production"},
+		"SWAGGER_ENABLED wins over the profile":     {"SWAGGER_ENV": "production", "SWAGGER_ENABLED": "true"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+			content := "title: API\n"
+			if _, ok := env["SWAGGER_ENABLED"]; !ok {
+				content = "enabled: true\n"
+			}
+			config, err := LoadConfigFile(writeConfigFile(t, "swagger.yaml", content))
+			require.NoError(t, err)
+			config.applyProfile()
+			assert.True(t, config.Enabled)
+		})
+	}
+
+	t.Setenv("SWAGGER_ENV", "staging-eu")
+	_, err = LoadConfigFile(path)
+	assert.EqualError(t, err, `SWAGGER_ENV: unknown profile "staging-eu"`)
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.Empty(t, validateConfig(NewConfig()))
+	assert.Empty(t, validateConfig(DefaultConfig()))
+
+	config := NewConfig().WithUIPath("docs").WithHost("https://api.example.com").WithBasePath("v1")
+	config.PostmanPath = "docs"
+	assert.Equal(t, []ConfigError{
+		{Field: "basePath", Message: `must start with "/", got "v1"`},
+		{Field: "uiPath", Message: `must start with "/", got "docs"`},
+		{Field: "postmanPath", Message: `must start with "/", got "docs"`},
+		{Field: "host", Message: `must be a host[:port] without scheme or path, got "https://api.example.com"`},
+		{Field: "postmanPath", Message: `"docs" collides with the UI routes under uiPath "docs"`},
+	}, validateConfig(config))
+}
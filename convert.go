@@ -0,0 +1,481 @@
+package swagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// refPrefixes maps Swagger 2.0 ref locations to their OpenAPI 3.0 equivalents
+var refPrefixes = map[string]string{
+	"#/definitions/": "#/components/schemas/",
+	"#/parameters/":  "#/components/parameters/",
+	"#/responses/":   "#/components/responses/",
+}
+
+// ConvertToOpenAPI3 converts a Swagger 2.0 spec (*SwaggerSpec, swag spec or raw JSON) into an OpenAPI 3.0.3 document.
+//
+// Host, basePath and schemes become servers, body and formData parameters become
+// requestBody, definitions become components/schemas and every $ref is rewritten.
+//
+// Example:
+//
+//	openapi, err := swagger.ConvertToOpenAPI3(swagSpec)
+func ConvertToOpenAPI3(spec interface{}) (map[string]interface{}, error) {
+	src, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(src) {
+		return cloneValue(src).(map[string]interface{}), nil
+	}
+	if version, _ := src["swagger"].(string); version != "" && version != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q", version)
+	}
+
+	src = rewriteRefs(cloneValue(src)).(map[string]interface{})
+	consumes := stringSlice(src["consumes"])
+	produces := stringSlice(src["produces"])
+
+	out := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    src["info"],
+		"paths":   map[string]interface{}{},
+	}
+	if out["info"] == nil {
+		out["info"] = map[string]interface{}{"title": "API", "version": "1.0.0"}
+	}
+	if servers := convertServers(src); len(servers) > 0 {
+		out["servers"] = servers
+	}
+	for _, key := range []string{"tags", "security", "externalDocs"} {
+		if v, ok := src[key]; ok {
+			out[key] = v
+		}
+	}
+	for key, v := range src {
+		if strings.HasPrefix(key, "x-") {
+			out[key] = v
+		}
+	}
+
+	components := make(map[string]interface{})
+	if definitions, ok := src["definitions"].(map[string]interface{}); ok && len(definitions) > 0 {
+		schemas := make(map[string]interface{}, len(definitions))
+		for name, def := range definitions {
+			schemas[name] = convertSchema(def)
+		}
+		components["schemas"] = schemas
+	}
+	if params, ok := src["parameters"].(map[string]interface{}); ok && len(params) > 0 {
+		converted := make(map[string]interface{})
+		for name, raw := range params {
+			if param, ok := raw.(map[string]interface{}); ok && param["in"] != "body" && param["in"] != "formData" {
+				converted[name] = convertParameter(param)
+			}
+		}
+		components["parameters"] = converted
+	}
+	if responses, ok := src["responses"].(map[string]interface{}); ok && len(responses) > 0 {
+		converted := make(map[string]interface{}, len(responses))
+		for name, raw := range responses {
+			converted[name] = convertResponse(raw, produces)
+		}
+		components["responses"] = converted
+	}
+	if securityDefs, ok := src["securityDefinitions"].(map[string]interface{}); ok && len(securityDefs) > 0 {
+		schemes := make(map[string]interface{}, len(securityDefs))
+		for name, raw := range securityDefs {
+			schemes[name] = convertSecurityScheme(raw)
+		}
+		components["securitySchemes"] = schemes
+	}
+	if len(components) > 0 {
+		out["components"] = components
+	}
+
+	paths := out["paths"].(map[string]interface{})
+	srcPaths, _ := src["paths"].(map[string]interface{})
+	for path, rawItem := range srcPaths {
+		item, ok := rawItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		converted := make(map[string]interface{})
+		for key, v := range item {
+			switch {
+			case key == "parameters":
+				params, _ := v.([]interface{})
+				converted[key] = convertParameterList(params)
+			case isHTTPMethod(key):
+				if op, ok := v.(map[string]interface{}); ok {
+					converted[key] = convertOperation(op, consumes, produces)
+				}
+			default:
+				converted[key] = v
+			}
+		}
+		paths[path] = converted
+	}
+
+	return out, nil
+}
+
+// rewriteRefs rewrites Swagger 2.0 refs to OpenAPI 3.0 component refs everywhere in value
+func rewriteRefs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if ref, ok := val.(string); ok && key == "$ref" {
+				for from, to := range refPrefixes {
+					if strings.HasPrefix(ref, from) {
+						v[key] = to + strings.TrimPrefix(ref, from)
+						break
+					}
+				}
+				continue
+			}
+			v[key] = rewriteRefs(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = rewriteRefs(val)
+		}
+	}
+	return value
+}
+
+// convertServers builds the servers list from host, basePath and schemes
+func convertServers(src map[string]interface{}) []interface{} {
+	host, _ := src["host"].(string)
+	basePath, _ := src["basePath"].(string)
+	if host == "" && (basePath == "" || basePath == "/") {
+		return nil
+	}
+	if host == "" {
+		return []interface{}{map[string]interface{}{"url": basePath}}
+	}
+
+	schemes := stringSlice(src["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	servers := make([]interface{}, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, map[string]interface{}{"url": scheme + "://" + host + strings.TrimSuffix(basePath, "/")})
+	}
+	return servers
+}
+
+// convertOperation converts parameters and responses of one operation
+func convertOperation(op map[string]interface{}, consumes, produces []string) map[string]interface{} {
+	if c := stringSlice(op["consumes"]); len(c) > 0 {
+		consumes = c
+	}
+	if p := stringSlice(op["produces"]); len(p) > 0 {
+		produces = p
+	}
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	out := make(map[string]interface{})
+	for key, v := range op {
+		switch key {
+		case "consumes", "produces", "schemes":
+			// Folded into content types and servers
+		case "parameters":
+			params, _ := v.([]interface{})
+			if converted := convertParameterList(params); len(converted) > 0 {
+				out["parameters"] = converted
+			}
+			if body := convertRequestBody(params, consumes); body != nil {
+				out["requestBody"] = body
+			}
+		case "responses":
+			responses, _ := v.(map[string]interface{})
+			converted := make(map[string]interface{}, len(responses))
+			for code, response := range responses {
+				converted[code] = convertResponse(response, produces)
+			}
+			out["responses"] = converted
+		default:
+			out[key] = v
+		}
+	}
+	return out
+}
+
+// convertParameterList converts non-body parameters and drops body and formData ones
+func convertParameterList(params []interface{}) []interface{} {
+	var out []interface{}
+	for _, raw := range params {
+		param, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, isRef := param["$ref"]; isRef {
+			out = append(out, param)
+			continue
+		}
+		if param["in"] == "body" || param["in"] == "formData" {
+			continue
+		}
+		out = append(out, convertParameter(param))
+	}
+	return out
+}
+
+// convertParameter moves the type keywords of a non-body parameter into a schema
+func convertParameter(param map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	schema := make(map[string]interface{})
+	for key, v := range param {
+		switch key {
+		case "name", "in", "description", "required", "deprecated", "allowEmptyValue":
+			out[key] = v
+		case "collectionFormat":
+			switch v {
+			case "csv":
+				out["style"] = "form"
+				out["explode"] = false
+			case "multi":
+				out["style"] = "form"
+				out["explode"] = true
+			case "ssv":
+				out["style"] = "spaceDelimited"
+			case "pipes":
+				out["style"] = "pipeDelimited"
+			}
+		case "x-example", "example":
+			out["example"] = v
+		default:
+			if strings.HasPrefix(key, "x-") {
+				out[key] = v
+			} else {
+				schema[key] = v
+			}
+		}
+	}
+	out["schema"] = convertSchema(schema)
+	return out
+}
+
+// convertRequestBody builds a requestBody from body or formData parameters
+func convertRequestBody(params []interface{}, consumes []string) map[string]interface{} {
+	var form []map[string]interface{}
+	for _, raw := range params {
+		param, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch param["in"] {
+		case "body":
+			body := map[string]interface{}{"content": mediaTypes(consumes, convertSchema(param["schema"]))}
+			if description, ok := param["description"]; ok {
+				body["description"] = description
+			}
+			if param["required"] == true {
+				body["required"] = true
+			}
+			return body
+		case "formData":
+			form = append(form, param)
+		}
+	}
+	if len(form) == 0 {
+		return nil
+	}
+
+	properties := make(map[string]interface{})
+	var required []interface{}
+	contentType := "application/x-www-form-urlencoded"
+	for _, param := range form {
+		name, _ := param["name"].(string)
+		if param["type"] == "file" {
+			contentType = "multipart/form-data"
+		}
+		properties[name] = convertParameter(param)["schema"]
+		if param["required"] == true {
+			required = append(required, name)
+		}
+	}
+	for _, c := range consumes {
+		if c == "multipart/form-data" {
+			contentType = c
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return map[string]interface{}{"content": mediaTypes([]string{contentType}, schema)}
+}
+
+// convertResponse moves a response schema and examples into content
+func convertResponse(raw interface{}, produces []string) interface{} {
+	response, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+	if _, isRef := response["$ref"]; isRef {
+		return response
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	out := make(map[string]interface{})
+	for key, v := range response {
+		switch key {
+		case "schema", "examples":
+		case "headers":
+			headers, _ := v.(map[string]interface{})
+			converted := make(map[string]interface{}, len(headers))
+			for name, h := range headers {
+				header, _ := h.(map[string]interface{})
+				converted[name] = convertHeader(header)
+			}
+			out[key] = converted
+		default:
+			out[key] = v
+		}
+	}
+	if _, ok := out["description"]; !ok {
+		out["description"] = ""
+	}
+
+	if schema, ok := response["schema"]; ok {
+		content := mediaTypes(produces, convertSchema(schema))
+		if examples, ok := response["examples"].(map[string]interface{}); ok {
+			for mime, example := range examples {
+				if media, ok := content[mime].(map[string]interface{}); ok {
+					media["example"] = example
+				}
+			}
+		}
+		out["content"] = content
+	}
+	return out
+}
+
+// convertHeader moves the type keywords of a response header into a schema
+func convertHeader(header map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	schema := make(map[string]interface{})
+	for key, v := range header {
+		if key == "description" || strings.HasPrefix(key, "x-") {
+			out[key] = v
+		} else {
+			schema[key] = v
+		}
+	}
+	out["schema"] = convertSchema(schema)
+	return out
+}
+
+// convertSecurityScheme converts a Swagger 2.0 security definition
+func convertSecurityScheme(raw interface{}) interface{} {
+	def, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+	switch def["type"] {
+	case "basic":
+		out := map[string]interface{}{"type": "http", "scheme": "basic"}
+		if description, ok := def["description"]; ok {
+			out["description"] = description
+		}
+		return out
+	case "oauth2":
+		flow := map[string]interface{}{"scopes": def["scopes"]}
+		if flow["scopes"] == nil {
+			flow["scopes"] = map[string]interface{}{}
+		}
+		if url, ok := def["authorizationUrl"]; ok {
+			flow["authorizationUrl"] = url
+		}
+		if url, ok := def["tokenUrl"]; ok {
+			flow["tokenUrl"] = url
+		}
+		flowName := map[interface{}]string{
+			"implicit":    "implicit",
+			"password":    "password",
+			"application": "clientCredentials",
+			"accessCode":  "authorizationCode",
+		}[def["flow"]]
+		out := map[string]interface{}{"type": "oauth2", "flows": map[string]interface{}{flowName: flow}}
+		if description, ok := def["description"]; ok {
+			out["description"] = description
+		}
+		return out
+	default:
+		return def
+	}
+}
+
+// convertSchema applies the schema differences between Swagger 2.0 and OpenAPI 3.0
+func convertSchema(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			switch key {
+			case "x-nullable":
+				out["nullable"] = val
+			case "discriminator":
+				if name, ok := val.(string); ok {
+					out[key] = map[string]interface{}{"propertyName": name}
+				} else {
+					out[key] = val
+				}
+			case "properties", "definitions", "patternProperties":
+				props, _ := val.(map[string]interface{})
+				converted := make(map[string]interface{}, len(props))
+				for name, prop := range props {
+					converted[name] = convertSchema(prop)
+				}
+				out[key] = converted
+			case "enum", "required", "example", "default":
+				out[key] = val
+			default:
+				out[key] = convertSchema(val)
+			}
+		}
+		if out["type"] == "file" {
+			out["type"] = "string"
+			out["format"] = "binary"
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = convertSchema(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// mediaTypes builds a content map with the same schema for every media type
+func mediaTypes(types []string, schema interface{}) map[string]interface{} {
+	content := make(map[string]interface{}, len(types))
+	for _, t := range types {
+		content[t] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+// isHTTPMethod reports whether a path item key holds an operation
+func isHTTPMethod(key string) bool {
+	for _, method := range httpMethods {
+		if key == method {
+			return true
+		}
+	}
+	return false
+}
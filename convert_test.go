@@ -0,0 +1,59 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToOpenAPI3(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"host": "api.example.com",
+		"basePath": "/v1",
+		"schemes": ["https"],
+		"paths": {
+			"/users": {
+				"get": {
+					"parameters": [{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"}],
+					"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/User"}}}}
+				},
+				"post": {
+					"consumes": ["application/json"],
+					"parameters": [{"name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}],
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/avatars": {
+				"post": {
+					"parameters": [{"name": "file", "in": "formData", "type": "file", "required": true}],
+					"responses": {"204": {"description": "Uploaded"}}
+				}
+			}
+		},
+		"definitions": {"User": {"type": "object", "properties": {"nickname": {"type": "string", "x-nullable": true}}}},
+		"securityDefinitions": {"Basic": {"type": "basic"}}
+	}`
+
+	converted, err := ConvertToOpenAPI3(spec)
+	require.NoError(t, err)
+	require.NoError(t, ValidateSpec(converted))
+
+	assert.Equal(t, "3.0.3", converted["openapi"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://api.example.com/v1"}}, converted["servers"])
+
+	get := func(pointer string) interface{} {
+		value, err := ResolveRef(converted, "#"+pointer)
+		require.NoError(t, err)
+		return value
+	}
+	assert.Equal(t, "#/components/schemas/User", get("/paths/~1users/get/responses/200/content/application~1json/schema/items/$ref"))
+	assert.Equal(t, true, get("/paths/~1users/get/parameters/0/explode"))
+	assert.Equal(t, "#/components/schemas/User", get("/paths/~1users/post/requestBody/content/application~1json/schema/$ref"))
+	assert.Equal(t, true, get("/paths/~1users/post/requestBody/required"))
+	assert.Equal(t, "binary", get("/paths/~1avatars/post/requestBody/content/multipart~1form-data/schema/properties/file/format"))
+	assert.Equal(t, true, get("/components/schemas/User/properties/nickname/nullable"))
+	assert.Equal(t, "http", get("/components/securitySchemes/Basic/type"))
+}
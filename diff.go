@@ -0,0 +1,409 @@
+package swagger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change is a single difference between two versions of a spec
+type Change struct {
+	// Breaking is true when existing clients may stop working
+	Breaking bool `json:"breaking"`
+
+	// Code identifies the kind of change (e.g., "endpoint-removed", "enum-narrowed")
+	Code string `json:"code"`
+
+	// Operation is the affected operation (e.g., "GET /users/{id}")
+	Operation string `json:"operation"`
+
+	// Location is where in the operation the change happened (e.g., "query parameter limit")
+	Location string `json:"location,omitempty"`
+
+	// Message describes the change
+	Message string `json:"message"`
+}
+
+// SpecDiff is the result of comparing two specs
+type SpecDiff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns only the breaking changes
+func (d *SpecDiff) Breaking() []Change {
+	var changes []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// HasBreaking reports whether any change is breaking
+func (d *SpecDiff) HasBreaking() bool {
+	return len(d.Breaking()) > 0
+}
+
+// schemaDirection tells compareSchema whether a schema is sent by clients or received by them
+type schemaDirection int
+
+const (
+	requestDirection schemaDirection = iota
+	responseDirection
+)
+
+// specDiffer compares two specs and collects changes
+type specDiffer struct {
+	oldSpec, newSpec map[string]interface{}
+	changes          []Change
+	// visited holds the refs being compared, to stop at circular schemas
+	visited map[string]bool
+}
+
+// DiffSpecs compares two specs (*SwaggerSpec, swag specs or raw JSON), for example the committed
+// docs/swagger.json against a freshly generated one, and classifies every change as breaking or not.
+//
+// Breaking changes: removed endpoints, new required parameters or body properties, optional
+// parameters becoming required, narrowed request enums, changed types, removed response fields
+// and removed success responses.
+//
+// Example:
+//
+//	oldDoc, _ := os.ReadFile("docs/swagger.json")
+//	diff, err := swagger.DiffSpecs(string(oldDoc), docs.SwaggerInfo.ReadDoc())
+//	if diff.HasBreaking() {
+//	    fmt.Println(diff.Markdown())
+//	}
+func DiffSpecs(oldSpec, newSpec interface{}) (*SpecDiff, error) {
+	oldMap, err := toSpecMap(oldSpec)
+	if err != nil {
+		return nil, fmt.Errorf("old spec: %w", err)
+	}
+	newMap, err := toSpecMap(newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("new spec: %w", err)
+	}
+
+	d := &specDiffer{oldSpec: oldMap, newSpec: newMap}
+	d.diffOperations()
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Breaking != d.changes[j].Breaking {
+			return d.changes[i].Breaking
+		}
+		return d.changes[i].Operation < d.changes[j].Operation
+	})
+	return &SpecDiff{Changes: d.changes}, nil
+}
+
+// add records a change
+func (d *specDiffer) add(breaking bool, code, operation, location, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Breaking:  breaking,
+		Code:      code,
+		Operation: operation,
+		Location:  location,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// operationKey identifies an operation independently of path parameter names
+func operationKey(op specOperation) string {
+	return op.Method + " " + pathParamPattern.ReplaceAllString(op.Path, "{}")
+}
+
+// diffOperations matches operations of both specs and compares them
+func (d *specDiffer) diffOperations() {
+	oldOps := make(map[string]specOperation)
+	for _, op := range specOperations(d.oldSpec) {
+		oldOps[operationKey(op)] = op
+	}
+	newOps := make(map[string]specOperation)
+	for _, op := range specOperations(d.newSpec) {
+		newOps[operationKey(op)] = op
+	}
+
+	for _, op := range specOperations(d.oldSpec) {
+		name := strings.ToUpper(op.Method) + " " + op.Path
+		newOp, ok := newOps[operationKey(op)]
+		if !ok {
+			d.add(true, "endpoint-removed", name, "", "endpoint %s was removed", name)
+			continue
+		}
+		d.diffOperation(strings.ToUpper(newOp.Method)+" "+newOp.Path, op, newOp)
+	}
+	for _, op := range specOperations(d.newSpec) {
+		if _, ok := oldOps[operationKey(op)]; !ok {
+			name := strings.ToUpper(op.Method) + " " + op.Path
+			d.add(false, "endpoint-added", name, "", "endpoint %s was added", name)
+		}
+	}
+}
+
+// diffOperation compares parameters and responses of one operation
+func (d *specDiffer) diffOperation(name string, oldOp, newOp specOperation) {
+	if newOp.Operation["deprecated"] == true && oldOp.Operation["deprecated"] != true {
+		d.add(false, "endpoint-deprecated", name, "", "endpoint %s was deprecated", name)
+	}
+
+	oldParams := make(map[string]map[string]interface{})
+	for _, param := range oldOp.parameters(d.oldSpec) {
+		oldParams[paramKey(param)] = param
+	}
+
+	newParams := newOp.parameters(d.newSpec)
+	for _, param := range newParams {
+		key := paramKey(param)
+		location := paramLocation(param)
+		required := param["required"] == true
+		oldParam, existed := oldParams[key]
+		delete(oldParams, key)
+
+		if !existed {
+			if required {
+				d.add(true, "required-param-added", name, location, "required %s was added", location)
+			} else {
+				d.add(false, "optional-param-added", name, location, "optional %s was added", location)
+			}
+			continue
+		}
+		if required && oldParam["required"] != true {
+			d.add(true, "param-became-required", name, location, "%s became required", location)
+		} else if !required && oldParam["required"] == true {
+			d.add(false, "param-became-optional", name, location, "%s became optional", location)
+		}
+
+		d.visited = make(map[string]bool)
+		if param["in"] == "body" {
+			d.compareSchema(name, location, oldParam["schema"], param["schema"], requestDirection)
+		} else {
+			d.compareSchema(name, location, oldParam, param, requestDirection)
+		}
+	}
+	for _, key := range sortedParamKeys(oldParams) {
+		location := paramLocation(oldParams[key])
+		d.add(false, "param-removed", name, location, "%s was removed", location)
+	}
+
+	oldResponses, _ := oldOp.Operation["responses"].(map[string]interface{})
+	newResponses, _ := newOp.Operation["responses"].(map[string]interface{})
+	for _, code := range sortedKeys(oldResponses) {
+		location := "response " + code
+		newResponse, ok := newResponses[code].(map[string]interface{})
+		if !ok {
+			// Clients may rely on documented success responses; dropping an error response is harmless
+			d.add(strings.HasPrefix(code, "2"), "response-removed", name, location, "%s was removed", location)
+			continue
+		}
+		oldResponse, _ := oldResponses[code].(map[string]interface{})
+		d.visited = make(map[string]bool)
+		d.compareSchema(name, location+" body", oldResponse["schema"], newResponse["schema"], responseDirection)
+	}
+	for _, code := range sortedKeys(newResponses) {
+		if _, ok := oldResponses[code]; !ok {
+			location := "response " + code
+			d.add(false, "response-added", name, location, "%s was added", location)
+		}
+	}
+}
+
+// compareSchema compares two schemas (or non-body parameters, which carry the same keywords)
+func (d *specDiffer) compareSchema(operation, location string, oldRaw, newRaw interface{}, direction schemaDirection) {
+	oldSchema, oldRef := d.resolveSchema(d.oldSpec, oldRaw)
+	newSchema, newRef := d.resolveSchema(d.newSpec, newRaw)
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	if oldRef != "" || newRef != "" {
+		visitKey := oldRef + "|" + newRef
+		if d.visited[visitKey] {
+			return
+		}
+		d.visited[visitKey] = true
+		defer delete(d.visited, visitKey)
+	}
+
+	oldType, _ := oldSchema["type"].(string)
+	newType, _ := newSchema["type"].(string)
+	if oldType != "" && newType != "" && oldType != newType {
+		d.add(true, "type-changed", operation, location, "type of %s changed from %s to %s", location, oldType, newType)
+		return
+	}
+	oldFormat, _ := oldSchema["format"].(string)
+	newFormat, _ := newSchema["format"].(string)
+	if oldFormat != "" && newFormat != "" && oldFormat != newFormat {
+		d.add(true, "format-changed", operation, location, "format of %s changed from %q to %q", location, oldFormat, newFormat)
+	}
+
+	d.compareEnum(operation, location, oldSchema, newSchema, direction)
+
+	oldProps, _ := oldSchema["properties"].(map[string]interface{})
+	newProps, _ := newSchema["properties"].(map[string]interface{})
+	oldRequired := toSet(stringSlice(oldSchema["required"]))
+	newRequired := toSet(stringSlice(newSchema["required"]))
+	for _, prop := range sortedKeys(oldProps) {
+		propLocation := joinLocation(location, prop)
+		newProp, ok := newProps[prop]
+		if !ok {
+			if direction == responseDirection {
+				d.add(true, "response-field-removed", operation, propLocation, "response field %s was removed", propLocation)
+			} else {
+				d.add(false, "request-field-removed", operation, propLocation, "request field %s was removed", propLocation)
+			}
+			continue
+		}
+		if direction == requestDirection && newRequired[prop] && !oldRequired[prop] {
+			d.add(true, "field-became-required", operation, propLocation, "request field %s became required", propLocation)
+		}
+		d.compareSchema(operation, propLocation, oldProps[prop], newProp, direction)
+	}
+	for _, prop := range sortedKeys(newProps) {
+		if _, ok := oldProps[prop]; ok {
+			continue
+		}
+		propLocation := joinLocation(location, prop)
+		if direction == requestDirection && newRequired[prop] {
+			d.add(true, "required-field-added", operation, propLocation, "required request field %s was added", propLocation)
+		} else {
+			d.add(false, "field-added", operation, propLocation, "field %s was added", propLocation)
+		}
+	}
+
+	if oldItems, ok := oldSchema["items"]; ok {
+		if newItems, ok := newSchema["items"]; ok {
+			d.compareSchema(operation, location+"[]", oldItems, newItems, direction)
+		}
+	}
+}
+
+// compareEnum reports removed and added enum values
+func (d *specDiffer) compareEnum(operation, location string, oldSchema, newSchema map[string]interface{}, direction schemaDirection) {
+	oldEnum, oldOK := oldSchema["enum"].([]interface{})
+	newEnum, newOK := newSchema["enum"].([]interface{})
+	if !oldOK && !newOK {
+		return
+	}
+
+	var removed, added []interface{}
+	if newOK {
+		for _, value := range oldEnum {
+			if !containsValue(newEnum, value) {
+				removed = append(removed, value)
+			}
+		}
+	}
+	if oldOK {
+		for _, value := range newEnum {
+			if !containsValue(oldEnum, value) {
+				added = append(added, value)
+			}
+		}
+	}
+
+	switch {
+	case !oldOK:
+		// A previously unrestricted value now only accepts a fixed set
+		d.add(direction == requestDirection, "enum-narrowed", operation, location, "%s is now restricted to %s", location, formatEnum(newEnum))
+	case len(removed) > 0:
+		d.add(direction == requestDirection, "enum-narrowed", operation, location, "values %s were removed from %s", formatEnum(removed), location)
+	}
+	if len(added) > 0 {
+		d.add(false, "enum-widened", operation, location, "values %s were added to %s", formatEnum(added), location)
+	}
+}
+
+// resolveSchema follows internal refs and returns the schema with the last ref followed
+func (d *specDiffer) resolveSchema(spec map[string]interface{}, raw interface{}) (map[string]interface{}, string) {
+	schema, _ := raw.(map[string]interface{})
+	ref := ""
+	for i := 0; schema != nil && i < 32; i++ {
+		r, ok := schema["$ref"].(string)
+		if !ok {
+			return schema, ref
+		}
+		ref = r
+		target, err := ResolveRef(spec, r)
+		if err != nil {
+			return nil, ref
+		}
+		schema, _ = target.(map[string]interface{})
+	}
+	return schema, ref
+}
+
+// Markdown renders the diff as a Markdown report suitable for a pull request comment
+func (d *SpecDiff) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## API changes\n\n")
+	if len(d.Changes) == 0 {
+		b.WriteString("No API changes.\n")
+		return b.String()
+	}
+
+	breaking := d.Breaking()
+	fmt.Fprintf(&b, "**%d breaking**, %d non-breaking changes\n", len(breaking), len(d.Changes)-len(breaking))
+
+	writeTable := func(title string, wantBreaking bool) {
+		var rows []Change
+		for _, change := range d.Changes {
+			if change.Breaking == wantBreaking {
+				rows = append(rows, change)
+			}
+		}
+		if len(rows) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", title)
+		b.WriteString("| Operation | Change | Details |\n")
+		b.WriteString("|-----------|--------|---------|\n")
+		for _, change := range rows {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", change.Operation, change.Code, markdownEscape(change.Message))
+		}
+	}
+	writeTable("⚠️ Breaking changes", true)
+	writeTable("Non-breaking changes", false)
+	return b.String()
+}
+
+// markdownEscape escapes characters that would break a Markdown table cell
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// paramKey identifies a parameter by location and name
+func paramKey(param map[string]interface{}) string {
+	return fmt.Sprint(param["in"], ":", param["name"])
+}
+
+// paramLocation describes a parameter for change messages (e.g., "query parameter limit")
+func paramLocation(param map[string]interface{}) string {
+	if param["in"] == "body" {
+		return "request body"
+	}
+	return fmt.Sprintf("%v parameter %v", param["in"], param["name"])
+}
+
+// sortedParamKeys returns the keys of a parameter map in order
+func sortedParamKeys(params map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinLocation appends a property name to a schema location
+func joinLocation(location, prop string) string {
+	return location + "." + prop
+}
+
+// toSet converts a slice of strings into a set
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,127 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffOldSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"paths": {
+		"/users": {
+			"get": {
+				"parameters": [{"name": "status", "in": "query", "type": "string", "enum": ["active", "banned", "pending"]}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/User"}}}}
+			},
+			"post": {
+				"parameters": [{"name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}],
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/users/{id}": {
+			"delete": {"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}], "responses": {"204": {"description": "Deleted"}}}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"properties": {"id": {"type": "integer"}, "name": {"type": "string"}, "email": {"type": "string"}}
+		}
+	}
+}`
+
+const diffNewSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "2.0"},
+	"paths": {
+		"/users": {
+			"get": {
+				"parameters": [
+					{"name": "status", "in": "query", "type": "string", "enum": ["active", "pending"]},
+					{"name": "tenant", "in": "header", "required": true, "type": "string"},
+					{"name": "limit", "in": "query", "type": "integer"}
+				],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/User"}}}}
+			},
+			"post": {
+				"parameters": [{"name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}],
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/users/{userId}/avatar": {
+			"get": {"parameters": [{"name": "userId", "in": "path", "required": true, "type": "string"}], "responses": {"200": {"description": "OK"}}}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {"id": {"type": "string"}, "name": {"type": "string"}, "nickname": {"type": "string"}}
+		}
+	}
+}`
+
+func changeCodes(changes []Change) map[string][]string {
+	codes := make(map[string][]string)
+	for _, change := range changes {
+		codes[change.Operation] = append(codes[change.Operation], change.Code)
+	}
+	return codes
+}
+
+func TestDiffSpecs(t *testing.T) {
+	diff, err := DiffSpecs(diffOldSpec, diffNewSpec)
+	require.NoError(t, err)
+
+	assert.True(t, diff.HasBreaking())
+	breaking := changeCodes(diff.Breaking())
+	assert.ElementsMatch(t, []string{"endpoint-removed"}, breaking["DELETE /users/{id}"])
+	assert.ElementsMatch(t, []string{"enum-narrowed", "required-param-added", "type-changed", "response-field-removed"}, breaking["GET /users"])
+	assert.ElementsMatch(t, []string{"type-changed", "field-became-required"}, breaking["POST /users"])
+
+	all := changeCodes(diff.Changes)
+	assert.Contains(t, all["GET /users"], "optional-param-added")
+	assert.Contains(t, all["GET /users"], "field-added")
+	assert.Equal(t, []string{"endpoint-added"}, all["GET /users/{userId}/avatar"])
+
+	for _, change := range diff.Changes {
+		if change.Code == "response-field-removed" {
+			assert.Equal(t, "response 200 body[].email", change.Location)
+		}
+	}
+}
+
+func TestDiffSpecsMatchesRenamedPathParams(t *testing.T) {
+	oldSpec := `{"paths": {"/users/{id}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+	newSpec := `{"paths": {"/users/{userId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+
+	diff, err := DiffSpecs(oldSpec, newSpec)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+	assert.Contains(t, diff.Markdown(), "No API changes.")
+}
+
+func TestSpecDiffMarkdown(t *testing.T) {
+	diff, err := DiffSpecs(diffOldSpec, diffNewSpec)
+	require.NoError(t, err)
+
+	markdown := diff.Markdown()
+	assert.Contains(t, markdown, "## API changes")
+	assert.Contains(t, markdown, "### ⚠️ Breaking changes")
+	assert.Contains(t, markdown, "| `DELETE /users/{id}` | endpoint-removed | endpoint DELETE /users/{id} was removed |")
+	assert.Contains(t, markdown, "### Non-breaking changes")
+}
+
+func TestDiffSpecsCircularSchemas(t *testing.T) {
+	spec := `{
+		"paths": {"/nodes": {"get": {"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Node"}}}}}},
+		"definitions": {"Node": {"type": "object", "properties": {"next": {"$ref": "#/definitions/Node"}}}}
+	}`
+
+	diff, err := DiffSpecs(spec, spec)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+}
@@ -0,0 +1,682 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// docModel is a spec flattened for the HTML and Markdown renderers
+type docModel struct {
+	Title       string
+	Description string
+	Version     string
+	BaseURL     string
+	Security    []docSecurity
+	Tags        []docTag
+	Schemas     []docSchema
+}
+
+// docSecurity describes a security definition
+type docSecurity struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// docTag groups the operations of a tag
+type docTag struct {
+	Name        string
+	Description string
+	Slug        string
+	Operations  []docOperation
+}
+
+// docOperation describes one operation
+type docOperation struct {
+	Anchor      string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	OperationID string
+	Deprecated  bool
+	Security    []string
+	Parameters  []docField
+	Body        *docBody
+	Responses   []docResponse
+	Curl        string
+}
+
+// docField is a parameter or schema property
+type docField struct {
+	Name        string
+	In          string
+	Type        docType
+	Required    bool
+	Description string
+}
+
+// docType is a readable type with the definition it refers to, if any
+type docType struct {
+	Label string
+
+	// Ref is the referenced definition name, empty for inline types
+	Ref string
+}
+
+// docBody is a request body
+type docBody struct {
+	Type        docType
+	Description string
+	Example     string
+}
+
+// docResponse is a documented response
+type docResponse struct {
+	Status      string
+	Description string
+	Type        docType
+	Example     string
+}
+
+// docSchema describes a definition
+type docSchema struct {
+	Name        string
+	Anchor      string
+	Description string
+	Type        docType
+	Properties  []docField
+	Example     string
+}
+
+// RenderHTML renders a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON) as a single
+// self-contained HTML page with a ReDoc-style navigation sidebar. The page has no external assets,
+// so it can be published on any static host.
+func RenderHTML(spec interface{}) ([]byte, error) {
+	model, err := newDocModel(spec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := docsTemplate.Execute(&buf, model); err != nil {
+		return nil, fmt.Errorf("failed to render HTML docs: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderMarkdown renders a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON) as a tree
+// of Markdown files keyed by file name: README.md with the overview, one file per tag with parameter
+// tables, examples and curl snippets, and schemas.md with the definitions. Tag files are named after
+// the lower-cased tag and numbered when that name is taken ("User" and "user" give user.md and user-2.md).
+//
+// Example:
+//
+//	files, err := swagger.RenderMarkdown(swagSpec)
+//	for name, data := range files {
+//	    os.WriteFile(filepath.Join("wiki", name), data, 0o644)
+//	}
+func RenderMarkdown(spec interface{}) (map[string][]byte, error) {
+	model, err := newDocModel(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	files["README.md"] = markdownIndex(model)
+	for _, tag := range model.Tags {
+		files[tag.Slug+".md"] = markdownTag(tag)
+	}
+	if len(model.Schemas) > 0 {
+		files["schemas.md"] = markdownSchemas(model)
+	}
+	return files, nil
+}
+
+// ExportHTML exports the Swagger spec as a self-contained HTML page
+func (s *Swagger) ExportHTML() (string, error) {
+	data, err := RenderHTML(s.spec)
+	return string(data), err
+}
+
+// ExportMarkdown exports the Swagger spec as Markdown files keyed by file name
+func (s *Swagger) ExportMarkdown() (map[string][]byte, error) {
+	return RenderMarkdown(s.spec)
+}
+
+// newDocModel flattens a spec for rendering
+func newDocModel(spec interface{}) (*docModel, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("documentation rendering supports Swagger 2.0 specs only")
+	}
+	generator, err := NewGenerator(specMap, 1)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := newCodeSampleBuilder(specMap)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := specMap["info"].(map[string]interface{})
+	model := &docModel{BaseURL: specBaseURL(specMap)}
+	model.Title, _ = info["title"].(string)
+	model.Description, _ = info["description"].(string)
+	model.Version, _ = info["version"].(string)
+	if model.Title == "" {
+		model.Title = "API"
+	}
+
+	definitions, _ := specMap["securityDefinitions"].(map[string]interface{})
+	for _, name := range sortedKeys(definitions) {
+		definition, _ := definitions[name].(map[string]interface{})
+		security := docSecurity{Name: name}
+		security.Type, _ = definition["type"].(string)
+		security.Description, _ = definition["description"].(string)
+		if security.Type == "apiKey" {
+			in, _ := definition["in"].(string)
+			key, _ := definition["name"].(string)
+			security.Type = fmt.Sprintf("API key (%s %s)", in, key)
+		}
+		model.Security = append(model.Security, security)
+	}
+
+	// Tags keep the order of the top-level tags list, then first use
+	tagIndex := make(map[string]int)
+	slugs := map[string]bool{"readme": true, "schemas": true}
+	addTag := func(name, description string) int {
+		if i, ok := tagIndex[name]; ok {
+			return i
+		}
+		tagIndex[name] = len(model.Tags)
+		model.Tags = append(model.Tags, docTag{Name: name, Description: description, Slug: uniqueSlug(name, slugs)})
+		return tagIndex[name]
+	}
+	tags, _ := specMap["tags"].([]interface{})
+	for _, tag := range tags {
+		t, _ := tag.(map[string]interface{})
+		name, _ := t["name"].(string)
+		description, _ := t["description"].(string)
+		if name != "" {
+			addTag(name, description)
+		}
+	}
+
+	global, _ := specMap["security"].([]interface{})
+	for _, op := range specOperations(specMap) {
+		operation := docOperation{
+			Method: strings.ToUpper(op.Method),
+			Path:   op.Path,
+			Curl:   curlSample(samples.request(op)),
+		}
+		operation.Summary, _ = op.Operation["summary"].(string)
+		operation.Description, _ = op.Operation["description"].(string)
+		operation.OperationID, _ = op.Operation["operationId"].(string)
+		operation.Deprecated = op.Operation["deprecated"] == true
+		operation.Anchor = docSlug(operation.OperationID)
+		if operation.Anchor == "" {
+			operation.Anchor = docSlug(op.Method + " " + op.Path)
+		}
+
+		requirements, ok := op.Operation["security"].([]interface{})
+		if !ok {
+			requirements = global
+		}
+		for _, requirement := range requirements {
+			names, _ := requirement.(map[string]interface{})
+			operation.Security = append(operation.Security, sortedKeys(names)...)
+		}
+
+		for _, param := range op.parameters(specMap) {
+			description, _ := param["description"].(string)
+			if param["in"] == "body" {
+				schema, _ := param["schema"].(map[string]interface{})
+				data, _ := json.MarshalIndent(generator.Generate(schema), "", "  ")
+				operation.Body = &docBody{Type: schemaDocType(schema), Description: description, Example: string(data)}
+				continue
+			}
+			field := docField{Type: schemaDocType(parameterSchema(param)), Required: param["required"] == true, Description: description}
+			field.Name, _ = param["name"].(string)
+			field.In, _ = param["in"].(string)
+			operation.Parameters = append(operation.Parameters, field)
+		}
+
+		responses, _ := op.Operation["responses"].(map[string]interface{})
+		for _, code := range sortedKeys(responses) {
+			response, _ := responses[code].(map[string]interface{})
+			if ref, _ := response["$ref"].(string); ref != "" {
+				if resolved, err := ResolveRef(specMap, ref); err == nil {
+					response, _ = resolved.(map[string]interface{})
+				}
+			}
+			entry := docResponse{Status: code}
+			entry.Description, _ = response["description"].(string)
+			if schema, ok := response["schema"].(map[string]interface{}); ok {
+				entry.Type = schemaDocType(schema)
+				if body, _ := mockBody(generator, response, ""); body != nil {
+					data, _ := json.MarshalIndent(body, "", "  ")
+					entry.Example = string(data)
+				}
+			}
+			operation.Responses = append(operation.Responses, entry)
+		}
+
+		tag := "default"
+		if names := stringSlice(op.Operation["tags"]); len(names) > 0 {
+			tag = names[0]
+		}
+		i := addTag(tag, "")
+		model.Tags[i].Operations = append(model.Tags[i].Operations, operation)
+	}
+
+	// Drop declared tags without operations and list untagged operations last
+	used := model.Tags[:0]
+	var untagged []docTag
+	for _, tag := range model.Tags {
+		switch {
+		case len(tag.Operations) == 0:
+		case tag.Name == "default":
+			untagged = append(untagged, tag)
+		default:
+			used = append(used, tag)
+		}
+	}
+	model.Tags = append(used, untagged...)
+
+	schemas, _ := specMap["definitions"].(map[string]interface{})
+	for _, name := range sortedKeys(schemas) {
+		schema, _ := schemas[name].(map[string]interface{})
+		entry := docSchema{Name: name, Anchor: docSlug(name), Type: schemaDocType(schema)}
+		entry.Description, _ = schema["description"].(string)
+		required := make(map[string]bool)
+		for _, field := range stringSlice(schema["required"]) {
+			required[field] = true
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for _, property := range sortedKeys(properties) {
+			p, _ := properties[property].(map[string]interface{})
+			field := docField{Name: property, Type: schemaDocType(p), Required: required[property]}
+			field.Description, _ = p["description"].(string)
+			entry.Properties = append(entry.Properties, field)
+		}
+		if value, err := generator.Definition(name); err == nil {
+			data, _ := json.MarshalIndent(value, "", "  ")
+			entry.Example = string(data)
+		}
+		model.Schemas = append(model.Schemas, entry)
+	}
+	return model, nil
+}
+
+// schemaDocType describes a schema as "string (date-time)", "array of Pet", "one of: a, b" and the like
+func schemaDocType(schema map[string]interface{}) docType {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := unescapePointerToken(strings.TrimPrefix(ref, "#/definitions/"))
+		return docType{Label: name, Ref: name}
+	}
+	typ, _ := schema["type"].(string)
+	if typ == "" {
+		if _, ok := schema["properties"]; ok {
+			typ = "object"
+		}
+	}
+	switch typ {
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		inner := schemaDocType(items)
+		inner.Label = "array of " + inner.Label
+		return inner
+	case "object", "":
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			inner := schemaDocType(additional)
+			inner.Label = "map of " + inner.Label
+			return inner
+		}
+		if typ == "" {
+			return docType{Label: "any"}
+		}
+	}
+
+	label := typ
+	if format, ok := schema["format"].(string); ok {
+		label += " (" + format + ")"
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		values := make([]string, len(enum))
+		for i, value := range enum {
+			values[i] = formatParamValue(value)
+		}
+		label += ", one of: " + strings.Join(values, ", ")
+	}
+	return docType{Label: label}
+}
+
+var docSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// docSlug turns a name into a file name and anchor ("Pet Store" becomes "pet-store")
+func docSlug(name string) string {
+	return strings.Trim(docSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// uniqueSlug returns the slug of name, numbered when it is taken ("user" then "user-2") and "tag"
+// when name has no ASCII letters or digits; README and schemas are taken by the other files
+func uniqueSlug(name string, taken map[string]bool) string {
+	base := docSlug(name)
+	if base == "" {
+		base = "tag"
+	}
+	slug := base
+	for i := 2; taken[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	taken[slug] = true
+	return slug
+}
+
+// markdownIndex renders the overview page
+func markdownIndex(model *docModel) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", model.Title)
+	if model.Version != "" {
+		fmt.Fprintf(&b, "Version: %s\n\n", model.Version)
+	}
+	if model.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", model.Description)
+	}
+	fmt.Fprintf(&b, "Base URL: `%s`\n\n", model.BaseURL)
+
+	if len(model.Security) > 0 {
+		b.WriteString("## Authentication\n\n| Name | Type | Description |\n| --- | --- | --- |\n")
+		for _, security := range model.Security {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", security.Name, markdownCell(security.Type), markdownCell(security.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Operations\n\n")
+	for _, tag := range model.Tags {
+		fmt.Fprintf(&b, "### [%s](%s.md)\n\n", tag.Name, tag.Slug)
+		if tag.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", tag.Description)
+		}
+		for _, op := range tag.Operations {
+			fmt.Fprintf(&b, "- [`%s %s`](%s.md#%s)", op.Method, op.Path, tag.Slug, op.Anchor)
+			if op.Summary != "" {
+				fmt.Fprintf(&b, " %s", op.Summary)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(model.Schemas) > 0 {
+		b.WriteString("## Schemas\n\n")
+		for _, schema := range model.Schemas {
+			fmt.Fprintf(&b, "- [%s](schemas.md#%s)\n", schema.Name, schema.Anchor)
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+// markdownTag renders the operations of one tag
+func markdownTag(tag docTag) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", tag.Name)
+	if tag.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", tag.Description)
+	}
+	b.WriteString("[Back to overview](README.md)\n\n")
+
+	for _, op := range tag.Operations {
+		title := op.Summary
+		if title == "" {
+			title = op.Method + " " + op.Path
+		}
+		fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n## %s\n\n```\n%s %s\n```\n\n", op.Anchor, title, op.Method, op.Path)
+		if op.Deprecated {
+			b.WriteString("> **Deprecated**\n\n")
+		}
+		if op.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", op.Description)
+		}
+		if len(op.Security) > 0 {
+			fmt.Fprintf(&b, "Authentication: %s\n\n", strings.Join(op.Security, ", "))
+		}
+
+		if len(op.Parameters) > 0 {
+			b.WriteString("### Parameters\n\n| Name | In | Type | Required | Description |\n| --- | --- | --- | --- | --- |\n")
+			for _, param := range op.Parameters {
+				fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", param.Name, param.In, markdownType(param.Type), yesNo(param.Required), markdownCell(param.Description))
+			}
+			b.WriteString("\n")
+		}
+
+		if op.Body != nil {
+			fmt.Fprintf(&b, "### Request body\n\n%s\n\n", markdownType(op.Body.Type))
+			if op.Body.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", op.Body.Description)
+			}
+			fmt.Fprintf(&b, "```json\n%s\n```\n\n", op.Body.Example)
+		}
+
+		if len(op.Responses) > 0 {
+			b.WriteString("### Responses\n\n| Status | Description | Schema |\n| --- | --- | --- |\n")
+			for _, response := range op.Responses {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", response.Status, markdownCell(response.Description), markdownType(response.Type))
+			}
+			b.WriteString("\n")
+			for _, response := range op.Responses {
+				if response.Example != "" {
+					fmt.Fprintf(&b, "Example `%s` response:\n\n```json\n%s\n```\n\n", response.Status, response.Example)
+				}
+			}
+		}
+
+		fmt.Fprintf(&b, "### Example\n\n```bash\n%s\n```\n\n", op.Curl)
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+// markdownSchemas renders the definitions
+func markdownSchemas(model *docModel) []byte {
+	var b strings.Builder
+	b.WriteString("# Schemas\n\n[Back to overview](README.md)\n\n")
+	for _, schema := range model.Schemas {
+		fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n## %s\n\n", schema.Anchor, schema.Name)
+		if schema.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", schema.Description)
+		}
+		if len(schema.Properties) > 0 {
+			b.WriteString("| Name | Type | Required | Description |\n| --- | --- | --- | --- |\n")
+			for _, property := range schema.Properties {
+				fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", property.Name, markdownType(property.Type), yesNo(property.Required), markdownCell(property.Description))
+			}
+			b.WriteString("\n")
+		} else {
+			fmt.Fprintf(&b, "Type: %s\n\n", markdownType(schema.Type))
+		}
+		if schema.Example != "" {
+			fmt.Fprintf(&b, "```json\n%s\n```\n\n", schema.Example)
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+// markdownType links types to their definition in schemas.md
+func markdownType(t docType) string {
+	if t.Label == "" {
+		return ""
+	}
+	if t.Ref != "" {
+		return fmt.Sprintf("[%s](schemas.md#%s)", markdownCell(t.Label), docSlug(t.Ref))
+	}
+	return markdownCell(t.Label)
+}
+
+// markdownCell escapes a value for a table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+}
+
+// yesNo renders a boolean for tables
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+var docsTemplate = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+	"slug":  docSlug,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+* { box-sizing: border-box; }
+body { margin: 0; font: 15px/1.6 -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; color: #333; }
+nav { position: fixed; top: 0; bottom: 0; width: 260px; overflow-y: auto; background: #fafafa; border-right: 1px solid #e5e5e5; padding: 16px 0; }
+nav a { display: block; padding: 4px 20px; color: #333; text-decoration: none; font-size: 14px; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+nav a:hover { background: #eee; }
+nav .tag { margin-top: 12px; font-weight: 600; text-transform: uppercase; font-size: 12px; color: #777; }
+main { margin-left: 260px; padding: 32px 48px; max-width: 1100px; }
+section { border-bottom: 1px solid #eee; padding: 24px 0; }
+h1 { margin-top: 0; }
+h3 { margin-bottom: 8px; }
+table { border-collapse: collapse; width: 100%; margin: 8px 0 16px; }
+th, td { text-align: left; padding: 6px 10px; border-bottom: 1px solid #eee; vertical-align: top; font-size: 14px; }
+th { color: #777; font-weight: 600; }
+pre { background: #263238; color: #eceff1; padding: 12px 16px; border-radius: 4px; overflow-x: auto; font-size: 13px; }
+code { font-family: Menlo, Consolas, monospace; }
+.endpoint { font-family: Menlo, Consolas, monospace; background: #f5f5f5; padding: 6px 10px; border-radius: 4px; }
+.method { display: inline-block; min-width: 56px; text-align: center; border-radius: 3px; color: #fff; font-size: 11px; font-weight: 700; padding: 1px 6px; margin-right: 6px; }
+.get { background: #2f8132; } .post { background: #186fad; } .put { background: #95507c; } .patch { background: #bf581d; } .delete { background: #cc3333; } .head, .options { background: #777; }
+.deprecated { text-decoration: line-through; }
+.required { color: #d41f1c; font-size: 12px; }
+</style>
+</head>
+<body>
+<nav>
+<a href="#overview"><strong>{{.Title}}</strong></a>
+{{- range .Tags}}
+<a class="tag" href="#tag-{{.Slug}}">{{.Name}}</a>
+{{- range .Operations}}
+<a href="#{{.Anchor}}"><span class="method {{lower .Method}}">{{.Method}}</span>{{if .Summary}}{{.Summary}}{{else}}{{.Path}}{{end}}</a>
+{{- end}}
+{{- end}}
+{{- if .Schemas}}
+<a class="tag" href="#schemas">Schemas</a>
+{{- range .Schemas}}
+<a href="#schema-{{.Anchor}}">{{.Name}}</a>
+{{- end}}
+{{- end}}
+</nav>
+<main>
+<section id="overview">
+<h1>{{.Title}}{{if .Version}} <small>{{.Version}}</small>{{end}}</h1>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+<p>Base URL: <code>{{.BaseURL}}</code></p>
+{{- if .Security}}
+<h3>Authentication</h3>
+<table>
+<tr><th>Name</th><th>Type</th><th>Description</th></tr>
+{{- range .Security}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</section>
+{{- range .Tags}}
+<section id="tag-{{.Slug}}">
+<h2>{{.Name}}</h2>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+</section>
+{{- range .Operations}}
+<section id="{{.Anchor}}">
+<h2{{if .Deprecated}} class="deprecated"{{end}}>{{if .Summary}}{{.Summary}}{{else}}{{.Method}} {{.Path}}{{end}}</h2>
+<div class="endpoint"><span class="method {{lower .Method}}">{{.Method}}</span>{{.Path}}</div>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Security}}
+<p>Authentication: {{range $i, $name := .Security}}{{if $i}}, {{end}}{{$name}}{{end}}</p>
+{{- end}}
+{{- if .Parameters}}
+<h3>Parameters</h3>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Description</th></tr>
+{{- range .Parameters}}
+<tr><td><code>{{.Name}}</code>{{if .Required}} <span class="required">required</span>{{end}}</td><td>{{.In}}</td><td>{{template "type" .Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with .Body}}
+<h3>Request body</h3>
+<p>{{template "type" .Type}}{{if .Description}} — {{.Description}}{{end}}</p>
+<pre><code>{{.Example}}</code></pre>
+{{- end}}
+{{- if .Responses}}
+<h3>Responses</h3>
+<table>
+<tr><th>Status</th><th>Description</th><th>Schema</th></tr>
+{{- range .Responses}}
+<tr><td>{{.Status}}</td><td>{{.Description}}</td><td>{{template "type" .Type}}</td></tr>
+{{- end}}
+</table>
+{{- range .Responses}}
+{{- if .Example}}
+<p>Example <code>{{.Status}}</code> response:</p>
+<pre><code>{{.Example}}</code></pre>
+{{- end}}
+{{- end}}
+{{- end}}
+<h3>Example</h3>
+<pre><code>{{.Curl}}</code></pre>
+</section>
+{{- end}}
+{{- end}}
+{{- if .Schemas}}
+<section id="schemas">
+<h2>Schemas</h2>
+</section>
+{{- range .Schemas}}
+<section id="schema-{{.Anchor}}">
+<h3>{{.Name}}</h3>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Properties}}
+<table>
+<tr><th>Name</th><th>Type</th><th>Description</th></tr>
+{{- range .Properties}}
+<tr><td><code>{{.Name}}</code>{{if .Required}} <span class="required">required</span>{{end}}</td><td>{{template "type" .Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>Type: {{template "type" .Type}}</p>
+{{- end}}
+{{- if .Example}}
+<pre><code>{{.Example}}</code></pre>
+{{- end}}
+</section>
+{{- end}}
+{{- end}}
+</main>
+</body>
+</html>
+{{define "type"}}{{if .Ref}}<a href="#schema-{{slug .Ref}}">{{.Label}}</a>{{else}}{{.Label}}{{end}}{{end}}`))
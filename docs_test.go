@@ -0,0 +1,109 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	files, err := RenderMarkdown(collectionSpec)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"README.md", "pets.md", "admin.md", "default.md", "schemas.md"}, names)
+
+	index := string(files["README.md"])
+	assert.Contains(t, index, "# Pets\n")
+	assert.Contains(t, index, "Base URL: `https://api.example.com/v1`")
+	assert.Contains(t, index, "| ApiKey | API key (query api_key) |  |")
+	assert.Contains(t, index, "- [`GET /pets`](pets.md#get-pets) List pets")
+	assert.Contains(t, index, "- [`POST /pets`](pets.md#createpet)")
+
+	pets := string(files["pets.md"])
+	assert.Contains(t, pets, "| status | query | string, one of: available | yes |  |")
+	assert.Contains(t, pets, "| limit | query | integer | no |  |")
+	assert.Contains(t, pets, "### Request body\n\n[Pet](schemas.md#pet)")
+	assert.Contains(t, pets, "```json\n{\n  \"name\": \"Rex\"\n}\n```")
+	assert.Contains(t, pets, "curl -X POST 'https://api.example.com/v1/pets' \\\n  -H 'Authorization: Bearer YOUR_TOKEN' \\\n  -H 'Content-Type: application/json' \\\n  -d '{\"name\":\"Rex\"}'")
+	assert.Contains(t, pets, "curl -X GET 'https://api.example.com/v1/pets?limit=10&status=available'")
+
+	admin := string(files["admin.md"])
+	assert.Contains(t, admin, "<a id=\"delete-pets-id\"></a>")
+	assert.Contains(t, admin, "Authentication: ApiKey")
+	assert.Contains(t, admin, "| 204 | Deleted |  |")
+
+	schemas := string(files["schemas.md"])
+	assert.Contains(t, schemas, "## Pet\n\n| Name | Type | Required | Description |")
+	assert.Contains(t, schemas, "| name | string | yes |  |")
+}
+
+func TestRenderMarkdownTagFileNames(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"paths": {
+			"/a": {"get": {"tags": ["User"], "responses": {"200": {"description": "OK"}}}},
+			"/b": {"get": {"tags": ["user"], "responses": {"200": {"description": "OK"}}}},
+			"/c": {"get": {"tags": ["schemas"], "responses": {"200": {"description": "OK"}}}},
+			"/d": {"get": {"tags": ["README"], "responses": {"200": {"description": "OK"}}}},
+			"/e": {"get": {"tags": ["用户"], "responses": {"200": {"description": "OK"}}}},
+			"/f": {"get": {"tags": ["注文"], "responses": {"200": {"description": "OK"}}}}
+		},
+		"definitions": {"Pet": {"type": "object"}}
+	}`
+	files, err := RenderMarkdown(spec)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"README.md", "schemas.md", "user.md", "user-2.md", "schemas-2.md", "readme-2.md", "tag.md", "tag-2.md",
+	}, names)
+	assert.Contains(t, string(files["schemas.md"]), "## Pet", "a tag does not overwrite the schemas")
+	assert.Contains(t, string(files["README.md"]), "### [user](user-2.md)")
+	assert.Contains(t, string(files["README.md"]), "### [注文](tag-2.md)")
+}
+
+func TestRenderHTML(t *testing.T) {
+	page, err := RenderHTML(collectionSpec)
+	require.NoError(t, err)
+	html := string(page)
+
+	assert.Contains(t, html, "<title>Pets</title>")
+	assert.Contains(t, html, `<a href="#createpet"><span class="method post">POST</span>/pets</a>`)
+	assert.Contains(t, html, `<p><a href="#schema-pet">Pet</a></p>`)
+	assert.Contains(t, html, `<section id="schema-pet">`)
+	assert.Contains(t, html, "curl -X DELETE &#39;https://api.example.com/v1/pets/7?api_key=YOUR_API_KEY&#39;")
+	assert.NotContains(t, html, "<script", "the page is self-contained")
+	assert.NotContains(t, html, "<link")
+}
+
+func TestSchemaDocType(t *testing.T) {
+	tests := []struct {
+		schema map[string]interface{}
+		want   docType
+	}{
+		{map[string]interface{}{"type": "string", "format": "date-time"}, docType{Label: "string (date-time)"}},
+		{map[string]interface{}{"$ref": "#/definitions/Pet"}, docType{Label: "Pet", Ref: "Pet"}},
+		{map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/definitions/Pet"}}, docType{Label: "array of Pet", Ref: "Pet"}},
+		{map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "integer"}}, docType{Label: "map of integer"}},
+		{map[string]interface{}{}, docType{Label: "any"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Label, func(t *testing.T) {
+			assert.Equal(t, tt.want, schemaDocType(tt.schema))
+		})
+	}
+}
+
+func TestRenderDocsRejectsOpenAPI3(t *testing.T) {
+	_, err := RenderHTML(`{"openapi": "3.0.0", "info": {"title": "API", "version": "1"}, "paths": {}}`)
+	assert.EqualError(t, err, "documentation rendering supports Swagger 2.0 specs only")
+}
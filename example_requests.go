@@ -0,0 +1,175 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ExampleRequest is a request for one operation built from examples or generated data
+type ExampleRequest struct {
+	// OperationID is the operationId, empty when the operation has none
+	OperationID string
+
+	// Method is the upper-case HTTP method
+	Method string
+
+	// Path is the path template as written in the spec (e.g., "/users/{id}")
+	Path string
+
+	// Request is ready to be served; its URL includes the spec's basePath
+	Request *http.Request
+}
+
+// Name identifies the operation as "METHOD /path"
+func (r ExampleRequest) Name() string {
+	return r.Method + " " + r.Path
+}
+
+// ExampleRequests builds a valid request for every operation of a Swagger 2.0 spec, sorted by path then method.
+// Parameters use documented example and default values, other values come from a Generator with the given seed.
+// Optional query, header and formData parameters are only sent when they have an example or default.
+func ExampleRequests(spec interface{}, seed int64) ([]ExampleRequest, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("example requests support Swagger 2.0 specs only")
+	}
+	generator, err := NewGenerator(specMap, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath, _ := specMap["basePath"].(string)
+	basePath = strings.TrimSuffix(basePath, "/")
+
+	var requests []ExampleRequest
+	for _, op := range specOperations(specMap) {
+		req, err := exampleRequest(generator, specMap, op, basePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(op.Method), op.Path, err)
+		}
+		id, _ := op.Operation["operationId"].(string)
+		requests = append(requests, ExampleRequest{
+			OperationID: id,
+			Method:      strings.ToUpper(op.Method),
+			Path:        op.Path,
+			Request:     req,
+		})
+	}
+	return requests, nil
+}
+
+// exampleRequest fills in the parameters of one operation
+func exampleRequest(generator *Generator, spec map[string]interface{}, op specOperation, basePath string) (*http.Request, error) {
+	path := op.Path
+	query := url.Values{}
+	form := url.Values{}
+	header := http.Header{}
+	var body []byte
+
+	for _, param := range op.parameters(spec) {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+
+		if in == "body" {
+			value, ok := param["x-example"]
+			if !ok {
+				value = generator.Generate(param["schema"])
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			body = data
+			header.Set("Content-Type", "application/json")
+			continue
+		}
+
+		_, hasExample := param["example"]
+		_, hasXExample := param["x-example"]
+		_, hasDefault := param["default"]
+		if in != "path" && param["required"] != true && !hasExample && !hasXExample && !hasDefault {
+			continue
+		}
+		schema := parameterSchema(param)
+		if hasXExample {
+			// swag writes parameter examples as x-example since Swagger 2.0 parameters have no example field
+			schema["example"] = param["x-example"]
+		}
+		values := parameterStrings(generator.Generate(schema), param)
+
+		switch in {
+		case "path":
+			value := ""
+			if len(values) > 0 {
+				value = values[0]
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+		case "query":
+			query[name] = values
+		case "header":
+			for _, value := range values {
+				header.Add(name, value)
+			}
+		case "formData":
+			form[name] = values
+		}
+	}
+
+	if len(form) > 0 && body == nil {
+		body = []byte(form.Encode())
+		header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	target := basePath + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(strings.ToUpper(op.Method), target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// parameterStrings formats a generated parameter value following its collectionFormat
+func parameterStrings(value interface{}, param map[string]interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		if value == nil {
+			return nil
+		}
+		return []string{formatParamValue(value)}
+	}
+
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = formatParamValue(item)
+	}
+	collectionFormat, _ := param["collectionFormat"].(string)
+	if collectionFormat == "multi" {
+		return parts
+	}
+	separator := map[string]string{"ssv": " ", "tsv": "\t", "pipes": "|"}[collectionFormat]
+	if separator == "" {
+		separator = ","
+	}
+	return []string{strings.Join(parts, separator)}
+}
+
+// formatParamValue formats a scalar for a URL or header, writing whole numbers without exponent
+func formatParamValue(value interface{}) string {
+	if n, ok := value.(float64); ok && n == float64(int64(n)) {
+		return fmt.Sprint(int64(n))
+	}
+	return fmt.Sprint(value)
+}
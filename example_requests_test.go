@@ -0,0 +1,61 @@
+package swagger
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExampleRequests(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"basePath": "/api/",
+		"paths": {
+			"/users/{id}": {
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "string", "x-example": "a b"}],
+				"put": {
+					"operationId": "updateUser",
+					"parameters": [
+						{"name": "ids", "in": "query", "type": "array", "items": {"type": "integer", "enum": [4]}, "collectionFormat": "pipes", "required": true, "minItems": 2, "maxItems": 2},
+						{"name": "verbose", "in": "query", "type": "boolean"},
+						{"name": "page", "in": "query", "type": "integer", "default": 2},
+						{"name": "X-Tenant", "in": "header", "type": "string", "required": true, "enum": ["acme"]},
+						{"name": "user", "in": "body", "schema": {"type": "object", "properties": {"name": {"type": "string", "example": "Jane"}}}}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			},
+			"/login": {
+				"post": {
+					"consumes": ["application/x-www-form-urlencoded"],
+					"parameters": [{"name": "user", "in": "formData", "type": "string", "required": true, "example": "jane"}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	requests, err := ExampleRequests(spec, 1)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	login := requests[0]
+	assert.Equal(t, "POST /login", login.Name())
+	assert.Equal(t, "application/x-www-form-urlencoded", login.Request.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(login.Request.Body)
+	assert.Equal(t, "user=jane", string(body))
+
+	update := requests[1]
+	assert.Equal(t, "updateUser", update.OperationID)
+	assert.Equal(t, "PUT", update.Method)
+	assert.Equal(t, "/api/users/a%20b", update.Request.URL.EscapedPath())
+	assert.Equal(t, "4|4", update.Request.URL.Query().Get("ids"))
+	assert.Equal(t, "2", update.Request.URL.Query().Get("page"), "optional parameters with a default are sent")
+	assert.False(t, update.Request.URL.Query().Has("verbose"), "other optional parameters are left out")
+	assert.Equal(t, "acme", update.Request.Header.Get("X-Tenant"))
+	body, _ = io.ReadAll(update.Request.Body)
+	assert.JSONEq(t, `{"name": "Jane"}`, string(body))
+}
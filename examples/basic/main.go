@@ -28,7 +28,9 @@ func main() {
 		WithBearerAuth(true).
 		WithContact("API Team", "api@example.com", "https://example.com")
 
-	swagger.Setup(router, swaggerConfig)
+	if err := swagger.Setup(router, swaggerConfig); err != nil {
+		log.Fatal(err)
+	}
 
 	// API routes
 	v1 := router.Group("/api/v1")
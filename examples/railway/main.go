@@ -37,7 +37,9 @@ func main() {
 		WithBearerAuth(true).
 		WithContact("API Team", "api@example.com", "https://example.com")
 
-	swagger.Setup(router, swaggerConfig)
+	if err := swagger.Setup(router, swaggerConfig); err != nil {
+		log.Fatal(err)
+	}
 
 	// Health check (no auth required)
 	router.GET("/health", healthHandler)
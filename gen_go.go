@@ -0,0 +1,853 @@
+package swagger
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GoClientOptions configures Go client generation
+type GoClientOptions struct {
+	// PackageName is the package clause of the generated file (default: "client")
+	PackageName string
+}
+
+// GenerateGoClient emits a Go client package for a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON).
+//
+// The generated file contains:
+//   - a struct per definition, with string enums as named types and constants
+//   - a Client with one method per operation, named after its operationId, taking a
+//     context.Context, path parameters, a Params struct for query/header/form parameters
+//     and the body, and returning the decoded 2xx response
+//   - With<Scheme> options applying the credentials of each security definition
+//   - an *APIError for non-2xx responses
+//
+// Example:
+//
+//	code, err := swagger.GenerateGoClient(swagSpec, swagger.GoClientOptions{PackageName: "petstore"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("petstore/client.go", code, 0o644)
+func GenerateGoClient(spec interface{}, options GoClientOptions) ([]byte, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("Go client generation supports Swagger 2.0 specs only")
+	}
+	if options.PackageName == "" {
+		options.PackageName = "client"
+	}
+
+	g := newGoGenerator(specMap)
+	source := g.generate(options.PackageName)
+	formatted, err := format.Source(source)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not compile: %w", err)
+	}
+	return formatted, nil
+}
+
+// goGenerator accumulates the declarations of a generated Go client
+type goGenerator struct {
+	spec map[string]interface{}
+
+	// typeNames maps definition names to Go type names
+	typeNames map[string]string
+
+	// declared holds the Go type names already emitted or queued
+	declared map[string]bool
+
+	// pending lists nested types found while emitting other declarations
+	pending []pendingType
+
+	usesTime bool
+}
+
+// definitions returns the definitions section of the spec
+func (g *goGenerator) definitions() map[string]interface{} {
+	definitions, _ := g.spec["definitions"].(map[string]interface{})
+	return definitions
+}
+
+// pendingType is a nested schema to emit as a named type
+type pendingType struct {
+	name   string
+	schema map[string]interface{}
+}
+
+func newGoGenerator(spec map[string]interface{}) *goGenerator {
+	g := &goGenerator{
+		spec:     spec,
+		declared: make(map[string]bool),
+	}
+
+	g.typeNames = definitionTypeNames(g.definitions())
+	for _, typeName := range g.typeNames {
+		g.declared[typeName] = true
+	}
+	return g
+}
+
+// definitionTypeNames maps definition names to exported type names.
+// swag names definitions after their package ("models.User"); the prefix is dropped unless names collide.
+func definitionTypeNames(definitions map[string]interface{}) map[string]string {
+	short := make(map[string]int)
+	for name := range definitions {
+		short[goName(lastSegment(name))]++
+	}
+	names := make(map[string]string, len(definitions))
+	for name := range definitions {
+		typeName := goName(lastSegment(name))
+		if short[typeName] > 1 {
+			typeName = goName(name)
+		}
+		names[name] = typeName
+	}
+	return names
+}
+
+// generate returns the unformatted source of the client
+func (g *goGenerator) generate(packageName string) []byte {
+	var types, methods strings.Builder
+
+	for _, name := range sortedKeys(g.definitions()) {
+		schema, _ := g.definitions()[name].(map[string]interface{})
+		g.writeType(&types, g.typeNames[name], schema)
+	}
+	security := g.writeSecurity(&methods)
+	g.writeOperations(&methods)
+	for len(g.pending) > 0 {
+		next := g.pending[0]
+		g.pending = g.pending[1:]
+		g.writeType(&types, next.name, next.schema)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by go-swagger. DO NOT EDIT.\n\n")
+	info, _ := g.spec["info"].(map[string]interface{})
+	title, _ := info["title"].(string)
+	version, _ := info["version"].(string)
+	fmt.Fprintf(&b, "// Package %s is a client for %s.\n", packageName, strings.TrimSpace(title+" "+version))
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+
+	imports := []string{"bytes", "context", "encoding/json", "fmt", "io", "net/http", "net/url", "strings"}
+	if g.usesTime {
+		imports = append(imports, "time")
+	}
+	b.WriteString("import (\n")
+	for _, path := range imports {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString(types.String())
+	b.WriteString(fmt.Sprintf(goClientRuntime, security))
+	b.WriteString(methods.String())
+	return []byte(b.String())
+}
+
+// writeType emits a named type for a schema
+func (g *goGenerator) writeType(b *strings.Builder, name string, schema map[string]interface{}) {
+	writeGoComment(b, "", name, schema["description"], fmt.Sprintf("%s is generated from the spec.", name))
+
+	if enum, ok := schema["enum"].([]interface{}); ok && schema["type"] == "string" {
+		fmt.Fprintf(b, "type %s string\n\n", name)
+		fmt.Fprintf(b, "// %s values\nconst (\n", name)
+		seen := make(map[string]bool)
+		for _, value := range enum {
+			s := fmt.Sprint(value)
+			constName := name + goName(s)
+			if seen[constName] {
+				continue
+			}
+			seen[constName] = true
+			fmt.Fprintf(b, "\t%s %s = %q\n", constName, name, s)
+		}
+		b.WriteString(")\n\n")
+		return
+	}
+
+	_, hasProperties := schema["properties"]
+	_, hasAllOf := schema["allOf"]
+	if !hasProperties && !hasAllOf {
+		fmt.Fprintf(b, "type %s %s\n\n", name, g.goType(schema, name))
+		return
+	}
+
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, part := range allOf {
+			partSchema, _ := part.(map[string]interface{})
+			if ref, ok := partSchema["$ref"].(string); ok {
+				// Embedded structs are flattened by encoding/json
+				fmt.Fprintf(b, "\t%s\n", g.refType(ref))
+				continue
+			}
+			g.writeFields(b, name, partSchema)
+		}
+	}
+	g.writeFields(b, name, schema)
+	b.WriteString("}\n\n")
+}
+
+// writeFields emits struct fields for the properties of a schema
+func (g *goGenerator) writeFields(b *strings.Builder, typeName string, schema map[string]interface{}) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	for _, name := range stringSlice(schema["required"]) {
+		required[name] = true
+	}
+	for _, name := range sortedKeys(properties) {
+		property, _ := properties[name].(map[string]interface{})
+		fieldName := goName(name)
+		fieldType := g.goType(property, typeName+fieldName)
+		tag := name
+		if !required[name] {
+			fieldType = optionalType(fieldType)
+			tag += ",omitempty"
+		}
+		writeGoComment(b, "\t", fieldName, property["description"], "")
+		fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", fieldName, fieldType, tag)
+	}
+}
+
+// goType returns the Go type of a schema, queuing nested enums and objects as named types called hint
+func (g *goGenerator) goType(schema map[string]interface{}, hint string) string {
+	if ref, ok := schema["$ref"].(string); ok {
+		return g.refType(ref)
+	}
+	if _, ok := schema["enum"]; ok && schema["type"] == "string" {
+		return g.queue(hint, schema)
+	}
+
+	switch schema["type"] {
+	case "string":
+		switch schema["format"] {
+		case "date-time":
+			g.usesTime = true
+			return "time.Time"
+		case "byte":
+			// encoding/json writes []byte as base64
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if schema["format"] == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema["format"] == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		return "[]" + g.goType(items, hint+"Item")
+	case "object", nil:
+		if _, ok := schema["properties"]; ok {
+			return g.queue(hint, schema)
+		}
+		if _, ok := schema["allOf"]; ok {
+			return g.queue(hint, schema)
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			return "map[string]" + g.goType(additional, hint+"Value")
+		}
+		if schema["type"] == "object" {
+			return "map[string]interface{}"
+		}
+	}
+	return "interface{}"
+}
+
+// refType returns the Go type of a definition reference
+func (g *goGenerator) refType(ref string) string {
+	name := unescapePointerToken(strings.TrimPrefix(ref, "#/definitions/"))
+	if typeName, ok := g.typeNames[name]; ok && strings.HasPrefix(ref, "#/definitions/") {
+		return typeName
+	}
+	return "interface{}"
+}
+
+// queue registers a nested named type and returns its name
+func (g *goGenerator) queue(name string, schema map[string]interface{}) string {
+	if g.declared[name] {
+		return name
+	}
+	g.declared[name] = true
+	g.pending = append(g.pending, pendingType{name: name, schema: schema})
+	return name
+}
+
+// writeSecurity emits an option per security definition and returns the body of Client.authorize
+func (g *goGenerator) writeSecurity(b *strings.Builder) string {
+	definitions, _ := g.spec["securityDefinitions"].(map[string]interface{})
+	var authorize strings.Builder
+	for _, name := range sortedKeys(definitions) {
+		definition, _ := definitions[name].(map[string]interface{})
+		option := "With" + goName(name)
+		field := fmt.Sprintf("c.credentials[%q]", name)
+		fmt.Fprintf(&authorize, "\t\tcase %q:\n", name)
+
+		switch definition["type"] {
+		case "basic":
+			fmt.Fprintf(b, "// %s authenticates requests with HTTP basic auth (%s)\n", option, name)
+			fmt.Fprintf(b, "func %s(username, password string) Option {\n", option)
+			fmt.Fprintf(b, "\treturn func(c *Client) { c.credentials[%q] = []string{username, password} }\n}\n\n", name)
+			fmt.Fprintf(&authorize, "\t\t\tif v, ok := %s; ok {\n\t\t\t\treq.SetBasicAuth(v[0], v[1])\n\t\t\t}\n", field)
+		case "apiKey":
+			in, _ := definition["in"].(string)
+			key, _ := definition["name"].(string)
+			fmt.Fprintf(b, "// %s authenticates requests with the %s API key (%s %q)\n", option, name, in, key)
+			fmt.Fprintf(b, "func %s(key string) Option {\n", option)
+			fmt.Fprintf(b, "\treturn func(c *Client) { c.credentials[%q] = []string{key} }\n}\n\n", name)
+			if in == "query" {
+				fmt.Fprintf(&authorize, "\t\t\tif v, ok := %s; ok {\n\t\t\t\tq := req.URL.Query()\n\t\t\t\tq.Set(%q, v[0])\n\t\t\t\treq.URL.RawQuery = q.Encode()\n\t\t\t}\n", field, key)
+			} else {
+				fmt.Fprintf(&authorize, "\t\t\tif v, ok := %s; ok {\n\t\t\t\treq.Header.Set(%q, v[0])\n\t\t\t}\n", field, key)
+			}
+		default:
+			fmt.Fprintf(b, "// %s authenticates requests with an OAuth2 access token (%s)\n", option, name)
+			fmt.Fprintf(b, "func %s(token string) Option {\n", option)
+			fmt.Fprintf(b, "\treturn func(c *Client) { c.credentials[%q] = []string{token} }\n}\n\n", name)
+			fmt.Fprintf(&authorize, "\t\t\tif v, ok := %s; ok {\n\t\t\t\treq.Header.Set(\"Authorization\", \"Bearer \"+v[0])\n\t\t\t}\n", field)
+		}
+	}
+	return authorize.String()
+}
+
+// goParam is an operation parameter with its Go names and type
+type goParam struct {
+	name, in, field, variable, typ, collectionFormat string
+	required                                         bool
+}
+
+// writeOperations emits a method per operation
+func (g *goGenerator) writeOperations(b *strings.Builder) {
+	used := make(map[string]int)
+	for _, op := range specOperations(g.spec) {
+		id, _ := op.Operation["operationId"].(string)
+		if id == "" {
+			id = op.Method + " " + pathParamPattern.ReplaceAllString(op.Path, "by $1")
+		}
+		name := goName(id)
+		if used[name]++; used[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, used[name])
+		}
+		g.writeOperation(b, name, op)
+	}
+}
+
+// writeOperation emits one client method and its Params struct
+func (g *goGenerator) writeOperation(b *strings.Builder, name string, op specOperation) {
+	var pathParams, otherParams []goParam
+	var body *goParam
+	taken := map[string]bool{"ctx": true, "params": true, "body": true, "req": true, "err": true, "out": true}
+	for _, param := range op.parameters(g.spec) {
+		p := goParam{
+			name:     fmt.Sprint(param["name"]),
+			in:       fmt.Sprint(param["in"]),
+			required: param["required"] == true,
+		}
+		p.field = goName(p.name)
+		p.collectionFormat, _ = param["collectionFormat"].(string)
+		switch p.in {
+		case "body":
+			schema, _ := param["schema"].(map[string]interface{})
+			p.typ = g.goType(schema, name+"Body")
+			body = &p
+		case "path":
+			p.typ = g.goType(parameterSchema(param), name+p.field)
+			p.variable = goVariable(p.name)
+			if taken[p.variable] {
+				p.variable += "Param"
+			}
+			taken[p.variable] = true
+			pathParams = append(pathParams, p)
+		default:
+			if param["type"] == "file" {
+				continue
+			}
+			p.typ = g.goType(parameterSchema(param), name+p.field)
+			otherParams = append(otherParams, p)
+		}
+	}
+
+	paramsType := name + "Params"
+	if len(otherParams) > 0 {
+		fmt.Fprintf(b, "// %s holds the query, header and form parameters of %s\n", paramsType, name)
+		fmt.Fprintf(b, "type %s struct {\n", paramsType)
+		for _, p := range otherParams {
+			typ := p.typ
+			if !p.required {
+				typ = optionalType(typ)
+			}
+			fmt.Fprintf(b, "\t// %s is the %s parameter %q\n\t%s %s\n", p.field, p.in, p.name, p.field, typ)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	// Result type of the first documented 2xx response with a schema
+	result := ""
+	responses, _ := op.Operation["responses"].(map[string]interface{})
+	for _, code := range sortedKeys(responses) {
+		response, _ := responses[code].(map[string]interface{})
+		if ref, ok := response["$ref"].(string); ok {
+			if resolved, err := ResolveRef(g.spec, ref); err == nil {
+				response, _ = resolved.(map[string]interface{})
+			}
+		}
+		schema, ok := response["schema"].(map[string]interface{})
+		if strings.HasPrefix(code, "2") && ok {
+			result = g.goType(schema, name+"Response")
+			break
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range pathParams {
+		args = append(args, p.variable+" "+p.typ)
+	}
+	if len(otherParams) > 0 {
+		args = append(args, "params *"+paramsType)
+	}
+	if body != nil {
+		args = append(args, "body "+body.typ)
+	}
+
+	returns, zero, out := "error", "", ""
+	if result != "" {
+		out = result
+		zero = "nil, "
+		if g.isStruct(result) {
+			returns = fmt.Sprintf("(*%s, error)", result)
+		} else {
+			returns = fmt.Sprintf("(%s, error)", result)
+			if !strings.HasPrefix(result, "[]") && !strings.HasPrefix(result, "map[") && result != "interface{}" {
+				zero = "out, "
+			}
+		}
+	}
+
+	method := strings.ToUpper(op.Method)
+	summary, _ := op.Operation["summary"].(string)
+	if summary != "" {
+		fmt.Fprintf(b, "// %s calls %s %s: %s\n", name, method, op.Path, strings.TrimSpace(summary))
+	} else {
+		fmt.Fprintf(b, "// %s calls %s %s\n", name, method, op.Path)
+	}
+	if op.Operation["deprecated"] == true {
+		b.WriteString("//\n// Deprecated: the operation is deprecated in the spec.\n")
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+	if out != "" {
+		fmt.Fprintf(b, "\tvar out %s\n", out)
+	}
+
+	// Path
+	path := op.Path
+	var pathExpr []string
+	last := 0
+	for _, loc := range pathParamPattern.FindAllStringSubmatchIndex(path, -1) {
+		pathExpr = append(pathExpr, fmt.Sprintf("%q", path[last:loc[0]]))
+		paramName := path[loc[2]:loc[3]]
+		for _, p := range pathParams {
+			if p.name == paramName {
+				pathExpr = append(pathExpr, fmt.Sprintf("url.PathEscape(%s)", formatGoValue(p.typ, p.variable)))
+			}
+		}
+		last = loc[1]
+	}
+	if last < len(path) || len(pathExpr) == 0 {
+		pathExpr = append(pathExpr, fmt.Sprintf("%q", path[last:]))
+	}
+	fmt.Fprintf(b, "\tpath := %s\n", strings.Join(pathExpr, " + "))
+
+	// Query, headers and form
+	b.WriteString("\tquery := url.Values{}\n")
+	hasForm, hasHeaders := false, false
+	for _, p := range otherParams {
+		if p.in == "formData" {
+			hasForm = true
+		}
+		if p.in == "header" {
+			hasHeaders = true
+		}
+	}
+	if hasForm {
+		b.WriteString("\tform := url.Values{}\n")
+	}
+	if hasHeaders {
+		b.WriteString("\theader := http.Header{}\n")
+	}
+	if len(otherParams) > 0 {
+		b.WriteString("\tif params != nil {\n")
+		for _, p := range otherParams {
+			target := map[string]string{"query": "query", "formData": "form", "header": "header"}[p.in]
+			if target == "" {
+				continue
+			}
+			value := "params." + p.field
+			if !p.required && !strings.HasPrefix(p.typ, "[]") && !strings.HasPrefix(p.typ, "map[") {
+				fmt.Fprintf(b, "\t\tif %s != nil {\n", value)
+				fmt.Fprintf(b, "\t\t\t%s.Set(%q, %s)\n\t\t}\n", target, p.name, formatGoValue(p.typ, "*"+value))
+				continue
+			}
+			if strings.HasPrefix(p.typ, "[]") {
+				if p.collectionFormat == "multi" {
+					fmt.Fprintf(b, "\t\tfor _, v := range %s {\n\t\t\t%s.Add(%q, fmt.Sprint(v))\n\t\t}\n", value, target, p.name)
+				} else {
+					separator := map[string]string{"ssv": " ", "tsv": "\t", "pipes": "|"}[p.collectionFormat]
+					if separator == "" {
+						separator = ","
+					}
+					fmt.Fprintf(b, "\t\tif len(%s) > 0 {\n\t\t\t%s.Set(%q, joinValues(%s, %q))\n\t\t}\n", value, target, p.name, value, separator)
+				}
+				continue
+			}
+			fmt.Fprintf(b, "\t\t%s.Set(%q, %s)\n", target, p.name, formatGoValue(p.typ, value))
+		}
+		b.WriteString("\t}\n")
+	}
+
+	// Request
+	switch {
+	case body != nil:
+		fmt.Fprintf(b, "\treq, err := c.newJSONRequest(ctx, %q, path, query, body)\n", method)
+	case hasForm:
+		fmt.Fprintf(b, "\treq, err := c.newRequest(ctx, %q, path, query, \"application/x-www-form-urlencoded\", strings.NewReader(form.Encode()))\n", method)
+	default:
+		fmt.Fprintf(b, "\treq, err := c.newRequest(ctx, %q, path, query, \"\", nil)\n", method)
+	}
+	fmt.Fprintf(b, "\tif err != nil {\n\t\treturn %serr\n\t}\n", zero)
+	if hasHeaders {
+		b.WriteString("\tfor key, values := range header {\n\t\treq.Header[key] = values\n\t}\n")
+	}
+	if schemes := operationSecurity(g.spec, op); len(schemes) > 0 {
+		quoted := make([]string, len(schemes))
+		for i, scheme := range schemes {
+			quoted[i] = fmt.Sprintf("%q", scheme)
+		}
+		fmt.Fprintf(b, "\tc.authorize(req, %s)\n", strings.Join(quoted, ", "))
+	}
+
+	// Response
+	if out == "" {
+		b.WriteString("\treturn c.do(req, nil)\n}\n\n")
+		return
+	}
+	fmt.Fprintf(b, "\tif err := c.do(req, &out); err != nil {\n\t\treturn %serr\n\t}\n", zero)
+	if g.isStruct(out) {
+		b.WriteString("\treturn &out, nil\n}\n\n")
+	} else {
+		b.WriteString("\treturn out, nil\n}\n\n")
+	}
+}
+
+// isStruct reports whether a Go type name was declared as a struct
+func (g *goGenerator) isStruct(typeName string) bool {
+	if typeName == "time.Time" {
+		return true
+	}
+	for name, goName := range g.typeNames {
+		if goName == typeName {
+			schema, _ := g.definitions()[name].(map[string]interface{})
+			return isObjectSchema(schema)
+		}
+	}
+	for _, pending := range g.pending {
+		if pending.name == typeName {
+			return isObjectSchema(pending.schema)
+		}
+	}
+	return false
+}
+
+// isObjectSchema reports whether a schema is generated as a struct
+func isObjectSchema(schema map[string]interface{}) bool {
+	_, hasProperties := schema["properties"]
+	_, hasAllOf := schema["allOf"]
+	return hasProperties || hasAllOf
+}
+
+// operationSecurity lists the security definition names an operation may use
+func operationSecurity(spec map[string]interface{}, op specOperation) []string {
+	requirements, ok := op.Operation["security"].([]interface{})
+	if !ok {
+		requirements, _ = spec["security"].([]interface{})
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, requirement := range requirements {
+		schemes, _ := requirement.(map[string]interface{})
+		for _, name := range sortedKeys(schemes) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// optionalType makes scalars and structs pointers so absent values can be told apart
+func optionalType(typ string) string {
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "interface{}" || strings.HasPrefix(typ, "*") {
+		return typ
+	}
+	return "*" + typ
+}
+
+// formatGoValue returns an expression formatting a value as a string for URLs and headers
+func formatGoValue(typ, expr string) string {
+	switch typ {
+	case "string":
+		return expr
+	case "time.Time":
+		return expr + ".Format(time.RFC3339)"
+	default:
+		return "fmt.Sprint(" + expr + ")"
+	}
+}
+
+// writeGoComment writes a doc comment from a description, or fallback when there is none
+func writeGoComment(b *strings.Builder, indent, name string, description interface{}, fallback string) {
+	text, _ := description.(string)
+	text = strings.TrimSpace(text)
+	if text == "" {
+		if fallback == "" {
+			return
+		}
+		text = fallback
+	} else if indent == "" && !strings.HasPrefix(text, name+" ") {
+		// Declarations are documented by sentences starting with their name
+		text = name + " is " + lowerFirst(text)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+// commonInitialisms are written in upper case in Go identifiers
+var commonInitialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DNS": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "SQL": true, "SSH": true, "TLS": true, "TTL": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName converts a spec name ("pet_id", "listPets", "models.User") into an exported Go identifier
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		upper := strings.ToUpper(word)
+		if commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	result := b.String()
+	if result == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(result)[0]) {
+		return "X" + result
+	}
+	return result
+}
+
+// goVariable converts a spec name into an unexported Go identifier that is not a keyword
+func goVariable(name string) string {
+	exported := goName(name)
+	words := splitWords(name)
+	var variable string
+	if len(words) > 0 && commonInitialisms[strings.ToUpper(words[0])] {
+		variable = strings.ToLower(words[0]) + exported[len(words[0]):]
+	} else {
+		variable = lowerFirst(exported)
+	}
+	if goKeywords[variable] {
+		variable += "_"
+	}
+	return variable
+}
+
+// goKeywords are reserved identifiers
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true,
+	"if": true, "import": true, "interface": true, "map": true, "package": true, "range": true,
+	"return": true, "select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+// splitWords splits a name on separators and lower-to-upper case changes
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && len(current) > 0 && unicode.IsLower(runes[i-1]) {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// lowerFirst lower-cases the first letter of a string
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// lastSegment returns the part of a definition name after the last dot
+func lastSegment(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// goClientRuntime is the client type and helpers shared by the generated methods.
+// The %s verb receives the cases of Client.authorize.
+const goClientRuntime = `// Client calls the API
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	credentials map[string][]string
+	editors     []func(*http.Request) error
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// NewClient creates a client for the API served at baseURL (e.g., "https://api.example.com/v1")
+func NewClient(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		httpClient:  http.DefaultClient,
+		credentials: make(map[string][]string),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRequestEditor registers a function called on every request before it is sent
+func WithRequestEditor(editor func(*http.Request) error) Option {
+	return func(c *Client) { c.editors = append(c.editors, editor) }
+}
+
+// APIError is returned for responses with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %%d: %%s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// newRequest builds a request for a path relative to the base URL
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) (*http.Request, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// newJSONRequest builds a request with a JSON body
+func (c *Client) newJSONRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %%w", err)
+	}
+	return c.newRequest(ctx, method, path, query, "application/json", bytes.NewReader(data))
+}
+
+// authorize applies the configured credentials of the given security definitions
+func (c *Client) authorize(req *http.Request, schemes ...string) {
+	for _, scheme := range schemes {
+		switch scheme {
+%s		}
+	}
+}
+
+// do sends a request and decodes a JSON response into out, which may be nil
+func (c *Client) do(req *http.Request, out interface{}) error {
+	for _, editor := range c.editors {
+		if err := editor(req); err != nil {
+			return err
+		}
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Body: data}
+	}
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %%w", err)
+	}
+	return nil
+}
+
+// joinValues formats array parameters
+func joinValues[T any](values []T, separator string) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprint(value)
+	}
+	return strings.Join(parts, separator)
+}
+
+`
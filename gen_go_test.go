@@ -0,0 +1,73 @@
+package swagger
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares output with a golden file, rewriting it when -update is set
+func assertGolden(t *testing.T, path string, output []byte) {
+	t.Helper()
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, output, 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test -update to create golden files")
+	assert.Equal(t, string(expected), string(output), "run go test -update to refresh golden files")
+}
+
+func TestGenerateGoClientGolden(t *testing.T) {
+	spec, err := LoadSpecFile(filepath.Join("testdata", "client", "petstore.json"))
+	require.NoError(t, err)
+
+	code, err := GenerateGoClient(spec, GoClientOptions{PackageName: "petstore"})
+	require.NoError(t, err)
+	assertGolden(t, filepath.Join("testdata", "client", "petstore.go.golden"), code)
+
+	// The output must type-check, not just parse
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "petstore.go", code, parser.ParseComments)
+	require.NoError(t, err)
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = config.Check("petstore", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+func TestGenerateGoClientDefaults(t *testing.T) {
+	code, err := GenerateGoClient(`{"swagger": "2.0", "info": {"title": "Empty", "version": "1"}, "paths": {}}`, GoClientOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "package client\n")
+
+	_, err = GenerateGoClient(`{"openapi": "3.0.0", "info": {"title": "API", "version": "1"}, "paths": {}}`, GoClientOptions{})
+	assert.Error(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"listPets":     "ListPets",
+		"pet_id":       "PetID",
+		"models.User":  "ModelsUser",
+		"X-Request-ID": "XRequestID",
+		"photoUrls":    "PhotoUrls",
+		"2fa":          "X2fa",
+		"api-key":      "APIKey",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, goName(input), input)
+	}
+	assert.Equal(t, "petID", goVariable("petId"))
+	assert.Equal(t, "idToken", goVariable("id_token"))
+	assert.Equal(t, "type_", goVariable("type"))
+}
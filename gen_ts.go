@@ -0,0 +1,585 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TypeScriptOptions configures TypeScript generation
+type TypeScriptOptions struct {
+	// SkipClient emits only the types, without the fetch clients
+	SkipClient bool
+}
+
+// GenerateTypeScript emits a TypeScript module for a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON).
+//
+// The module contains an interface or type alias per definition, string literal unions for
+// enums, unions for oneOf/anyOf (narrowed on the discriminator property when one is set), and a
+// fetch client class per tag with one typed method per operation. Output is sorted so regenerating an unchanged spec produces an identical file.
+//
+// Example:
+//
+//	code, err := swagger.GenerateTypeScript(swagSpec, swagger.TypeScriptOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("web/src/api.ts", code, 0o644)
+func GenerateTypeScript(spec interface{}, options TypeScriptOptions) ([]byte, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("TypeScript generation supports Swagger 2.0 specs only")
+	}
+
+	definitions, _ := specMap["definitions"].(map[string]interface{})
+	g := &tsGenerator{spec: specMap, typeNames: definitionTypeNames(definitions)}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by go-swagger. DO NOT EDIT.\n")
+	info, _ := specMap["info"].(map[string]interface{})
+	if title, _ := info["title"].(string); title != "" {
+		version, _ := info["version"].(string)
+		fmt.Fprintf(&b, "// %s\n", strings.TrimSpace(title+" "+version))
+	}
+	b.WriteString("\n")
+
+	for _, name := range sortedKeys(definitions) {
+		schema, _ := definitions[name].(map[string]interface{})
+		g.writeDefinition(&b, g.typeNames[name], schema)
+	}
+	if !options.SkipClient {
+		g.writeClients(&b)
+	}
+	return []byte(b.String()), nil
+}
+
+// tsGenerator renders TypeScript for the schemas and operations of a spec
+type tsGenerator struct {
+	spec      map[string]interface{}
+	typeNames map[string]string
+}
+
+// writeDefinition emits an interface for object definitions and a type alias otherwise
+func (g *tsGenerator) writeDefinition(b *strings.Builder, name string, schema map[string]interface{}) {
+	writeTSComment(b, "", schema["description"])
+	if _, ok := schema["properties"]; ok && schema["allOf"] == nil && schema["x-nullable"] != true {
+		fmt.Fprintf(b, "export interface %s ", name)
+		b.WriteString(g.objectType(schema, ""))
+		b.WriteString("\n\n")
+		return
+	}
+	fmt.Fprintf(b, "export type %s = %s;\n\n", name, g.tsType(schema, ""))
+}
+
+// tsType returns the TypeScript type of a schema; indent is used for multi-line object literals
+func (g *tsGenerator) tsType(schema map[string]interface{}, indent string) string {
+	typ := g.baseType(schema, indent)
+	if schema["x-nullable"] == true || schema["nullable"] == true {
+		return typ + " | null"
+	}
+	return typ
+}
+
+// baseType returns the TypeScript type of a schema without nullability
+func (g *tsGenerator) baseType(schema map[string]interface{}, indent string) string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := unescapePointerToken(strings.TrimPrefix(ref, "#/definitions/"))
+		if typeName, ok := g.typeNames[name]; ok {
+			return typeName
+		}
+		return "unknown"
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		literals := make([]string, 0, len(enum))
+		for _, value := range enum {
+			literal, _ := json.Marshal(value)
+			literals = append(literals, string(literal))
+		}
+		return strings.Join(literals, " | ")
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if choices, ok := schema[key].([]interface{}); ok && len(choices) > 0 {
+			if property, mapping := discriminator(schema); property != "" {
+				return g.discriminatedUnion(choices, property, mapping, indent)
+			}
+			return g.combine(choices, " | ", indent)
+		}
+	}
+	if parts, ok := schema["allOf"].([]interface{}); ok && len(parts) > 0 {
+		if _, hasProperties := schema["properties"]; hasProperties {
+			own := make(map[string]interface{})
+			for _, key := range []string{"properties", "required"} {
+				if value, ok := schema[key]; ok {
+					own[key] = value
+				}
+			}
+			parts = append(append([]interface{}{}, parts...), own)
+		}
+		return g.combine(parts, " & ", indent)
+	}
+
+	switch schema["type"] {
+	case "string":
+		if schema["format"] == "binary" {
+			return "Blob"
+		}
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "file":
+		return "Blob"
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		item := g.tsType(items, indent)
+		if strings.Contains(item, " ") && !strings.HasPrefix(item, "{") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object", nil:
+		if _, ok := schema["properties"]; ok {
+			return g.objectType(schema, indent)
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			return "Record<string, " + g.tsType(additional, indent) + ">"
+		}
+		if schema["type"] == "object" {
+			return "Record<string, unknown>"
+		}
+	}
+	return "unknown"
+}
+
+// combine joins the types of several schemas, wrapping unions inside intersections
+func (g *tsGenerator) combine(schemas []interface{}, separator, indent string) string {
+	types := make([]string, 0, len(schemas))
+	for _, part := range schemas {
+		schema, _ := part.(map[string]interface{})
+		typ := g.tsType(schema, indent)
+		if separator == " & " && strings.Contains(typ, " | ") {
+			typ = "(" + typ + ")"
+		}
+		types = append(types, typ)
+	}
+	return strings.Join(types, separator)
+}
+
+// discriminator returns the discriminator property of a schema, given as a name (Swagger 2.0)
+// or as an object with propertyName and mapping (OpenAPI 3)
+func discriminator(schema map[string]interface{}) (string, map[string]interface{}) {
+	switch value := schema["discriminator"].(type) {
+	case string:
+		return value, nil
+	case map[string]interface{}:
+		property, _ := value["propertyName"].(string)
+		mapping, _ := value["mapping"].(map[string]interface{})
+		return property, mapping
+	}
+	return "", nil
+}
+
+// discriminatedUnion joins choices into a union whose referenced members are narrowed on the
+// discriminator property. A member's value comes from the mapping, else from a single-value enum
+// on its own property, else it is the definition name.
+func (g *tsGenerator) discriminatedUnion(choices []interface{}, property string, mapping map[string]interface{}, indent string) string {
+	values := make(map[string]string)
+	for _, value := range sortedKeys(mapping) {
+		ref, _ := mapping[value].(string)
+		if !strings.HasPrefix(ref, "#") {
+			ref = "#/definitions/" + escapePointerToken(ref)
+		}
+		if _, seen := values[ref]; !seen {
+			values[ref] = value
+		}
+	}
+
+	types := make([]string, 0, len(choices))
+	for _, part := range choices {
+		schema, _ := part.(map[string]interface{})
+		typ := g.tsType(schema, indent)
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			types = append(types, typ)
+			continue
+		}
+		value, ok := values[ref]
+		if !ok {
+			value = unescapePointerToken(strings.TrimPrefix(ref, "#/definitions/"))
+			if resolved, err := ResolveRef(g.spec, ref); err == nil {
+				member, _ := resolved.(map[string]interface{})
+				properties, _ := member["properties"].(map[string]interface{})
+				own, _ := properties[property].(map[string]interface{})
+				if enum, _ := own["enum"].([]interface{}); len(enum) == 1 {
+					if literal, ok := enum[0].(string); ok {
+						value = literal
+					}
+				}
+			}
+		}
+		literal, _ := json.Marshal(value)
+		types = append(types, fmt.Sprintf("(%s & { %s: %s })", typ, tsPropertyName(property), literal))
+	}
+	return strings.Join(types, " | ")
+}
+
+// objectType renders an object literal type with one property per line
+func (g *tsGenerator) objectType(schema map[string]interface{}, indent string) string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	if len(properties) == 0 {
+		return "{}"
+	}
+	required := make(map[string]bool)
+	for _, name := range stringSlice(schema["required"]) {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	inner := indent + "  "
+	for _, name := range sortedKeys(properties) {
+		property, _ := properties[name].(map[string]interface{})
+		writeTSComment(&b, inner, property["description"])
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		readonly := ""
+		if property["readOnly"] == true {
+			readonly = "readonly "
+		}
+		fmt.Fprintf(&b, "%s%s%s%s: %s;\n", inner, readonly, tsPropertyName(name), optional, g.tsType(property, inner))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsOperation is an operation rendered as a client method
+type tsOperation struct {
+	specOperation
+	name string
+}
+
+// writeClients emits the shared fetch helper and a client class per tag
+func (g *tsGenerator) writeClients(b *strings.Builder) {
+	byTag := make(map[string][]tsOperation)
+	used := make(map[string]int)
+	for _, op := range specOperations(g.spec) {
+		tag := "default"
+		if tags := stringSlice(op.Operation["tags"]); len(tags) > 0 {
+			tag = tags[0]
+		}
+		id, _ := op.Operation["operationId"].(string)
+		if id == "" {
+			id = op.Method + " " + pathParamPattern.ReplaceAllString(op.Path, "by $1")
+		}
+		name := lowerFirst(goName(id))
+		key := tag + " " + name
+		if used[key]++; used[key] > 1 {
+			name = fmt.Sprintf("%s%d", name, used[key])
+		}
+		byTag[tag] = append(byTag[tag], tsOperation{specOperation: op, name: name})
+	}
+
+	b.WriteString(tsClientRuntime)
+	for _, tag := range sortedTags(byTag) {
+		fmt.Fprintf(b, "export class %sClient {\n", goName(tag))
+		b.WriteString("  constructor(private readonly options: ClientOptions) {}\n")
+		for _, op := range byTag[tag] {
+			b.WriteString("\n")
+			g.writeMethod(b, op)
+		}
+		b.WriteString("}\n\n")
+	}
+}
+
+// writeMethod emits a client method taking path parameters, a params object and the body
+func (g *tsGenerator) writeMethod(b *strings.Builder, op tsOperation) {
+	var args, pathNames []string
+	var params []string
+	paramsRequired := false
+	query, headers, form := []string{}, []string{}, []string{}
+	bodyArg := ""
+	// form parameters are sent as multipart/form-data when the operation consumes it or uploads
+	// a file, as application/x-www-form-urlencoded otherwise
+	consumes := stringSlice(op.Operation["consumes"])
+	if len(consumes) == 0 {
+		consumes = stringSlice(g.spec["consumes"])
+	}
+	multipart := false
+	for _, mediaType := range consumes {
+		multipart = multipart || strings.HasPrefix(mediaType, "multipart/form-data")
+	}
+
+	parameters := op.parameters(g.spec)
+	for _, param := range parameters {
+		if in := param["in"]; in != "body" && in != "path" && param["required"] == true {
+			// params cannot be undefined
+			paramsRequired = true
+		}
+	}
+	for _, param := range parameters {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required := param["required"] == true
+		switch in {
+		case "body":
+			schema, _ := param["schema"].(map[string]interface{})
+			optional := ""
+			if !required {
+				optional = "?"
+			}
+			bodyArg = fmt.Sprintf("body%s: %s", optional, g.tsType(schema, "  "))
+		case "path":
+			variable := lowerFirst(goName(name))
+			args = append(args, fmt.Sprintf("%s: %s", variable, g.tsType(parameterSchema(param), "  ")))
+			pathNames = append(pathNames, name, variable)
+		default:
+			optional := "?"
+			if required {
+				optional = ""
+			}
+			typ := g.tsType(parameterSchema(param), "  ")
+			if param["type"] == "file" {
+				typ = "Blob"
+				multipart = multipart || in == "formData"
+			}
+			params = append(params, fmt.Sprintf("%s%s: %s", tsPropertyName(name), optional, typ))
+			value := tsAccess("params", name, !paramsRequired)
+			if collectionFormat, _ := param["collectionFormat"].(string); param["type"] == "array" && collectionFormat != "multi" {
+				separator := map[string]string{"ssv": " ", "tsv": "\t", "pipes": "|"}[collectionFormat]
+				if separator == "" {
+					separator = ","
+				}
+				value = fmt.Sprintf("%s?.join(%q)", value, separator)
+			}
+			entry := fmt.Sprintf("%s: %s", tsPropertyName(name), value)
+			switch in {
+			case "query":
+				query = append(query, entry)
+			case "header":
+				headers = append(headers, entry)
+			case "formData":
+				form = append(form, entry)
+			}
+		}
+	}
+	if len(params) > 0 {
+		optional := "?"
+		if paramsRequired {
+			optional = ""
+		}
+		args = append(args, fmt.Sprintf("params%s: { %s }", optional, strings.Join(params, "; ")))
+	}
+	if bodyArg != "" {
+		args = append(args, bodyArg)
+	}
+
+	result := "void"
+	responses, _ := op.Operation["responses"].(map[string]interface{})
+	for _, code := range sortedKeys(responses) {
+		response, _ := responses[code].(map[string]interface{})
+		if ref, ok := response["$ref"].(string); ok {
+			if resolved, err := ResolveRef(g.spec, ref); err == nil {
+				response, _ = resolved.(map[string]interface{})
+			}
+		}
+		if schema, ok := response["schema"].(map[string]interface{}); ok && strings.HasPrefix(code, "2") {
+			result = g.tsType(schema, "  ")
+			break
+		}
+	}
+
+	var comment []string
+	if summary, _ := op.Operation["summary"].(string); summary != "" {
+		comment = append(comment, strings.TrimSpace(summary))
+	}
+	comment = append(comment, strings.ToUpper(op.Method)+" "+op.Path)
+	if op.Operation["deprecated"] == true {
+		comment = append(comment, "@deprecated")
+	}
+	fmt.Fprintf(b, "  /**\n")
+	for _, line := range comment {
+		fmt.Fprintf(b, "   * %s\n", line)
+	}
+	fmt.Fprintf(b, "   */\n")
+
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n", op.name, strings.Join(args, ", "), result)
+	path := fmt.Sprintf("%q", op.Path)
+	if len(pathNames) > 0 {
+		path = "`" + op.Path + "`"
+		for i := 0; i < len(pathNames); i += 2 {
+			path = strings.ReplaceAll(path, "{"+pathNames[i]+"}", "${encodeURIComponent(String("+pathNames[i+1]+"))}")
+		}
+	}
+	var init []string
+	if len(query) > 0 {
+		init = append(init, fmt.Sprintf("query: { %s },", strings.Join(query, ", ")))
+	}
+	if len(headers) > 0 {
+		init = append(init, fmt.Sprintf("headers: { %s },", strings.Join(headers, ", ")))
+	}
+	if len(form) > 0 {
+		field := "form"
+		if multipart {
+			field = "multipart"
+		}
+		init = append(init, fmt.Sprintf("%s: { %s },", field, strings.Join(form, ", ")))
+	}
+	if bodyArg != "" {
+		init = append(init, "body,")
+	}
+	if len(init) == 0 {
+		fmt.Fprintf(b, "    return request<%s>(this.options, %q, %s, {});\n  }\n", result, strings.ToUpper(op.Method), path)
+		return
+	}
+	fmt.Fprintf(b, "    return request<%s>(this.options, %q, %s, {\n", result, strings.ToUpper(op.Method), path)
+	for _, line := range init {
+		fmt.Fprintf(b, "      %s\n", line)
+	}
+	b.WriteString("    });\n  }\n")
+}
+
+// sortedTags returns the tag names in order, with "default" last
+func sortedTags(byTag map[string][]tsOperation) []string {
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		if tag != "default" {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	if _, ok := byTag["default"]; ok {
+		tags = append(tags, "default")
+	}
+	return tags
+}
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsPropertyName quotes property names that are not identifiers
+func tsPropertyName(name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// tsAccess reads property name of object, through optional chaining when object may be undefined
+func tsAccess(object, name string, optional bool) string {
+	chain := "."
+	if optional {
+		chain = "?."
+	}
+	if tsIdentifierPattern.MatchString(name) {
+		return object + chain + name
+	}
+	if !optional {
+		chain = ""
+	}
+	return fmt.Sprintf("%s%s[%q]", object, chain, name)
+}
+
+// writeTSComment writes a JSDoc comment for a description
+func writeTSComment(b *strings.Builder, indent string, description interface{}) {
+	text, _ := description.(string)
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "*/", "* /"), "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s * %s\n", indent, strings.TrimSpace(line))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
+
+// tsClientRuntime is the fetch helper shared by the generated client classes
+const tsClientRuntime = `export interface ClientOptions {
+  /** Base URL of the API, e.g. "https://api.example.com/v1" or "/api" */
+  baseUrl: string;
+  /** fetch implementation (default: globalThis.fetch) */
+  fetch?: typeof fetch;
+  /** Headers sent with every request, e.g. for authentication */
+  headers?: Record<string, string> | (() => Record<string, string> | Promise<Record<string, string>>);
+}
+
+/** Error thrown for responses with a non-2xx status */
+export class ApiError extends Error {
+  constructor(
+    public readonly status: number,
+    public readonly body: unknown,
+  ) {
+    super(` + "`unexpected status ${status}`" + `);
+    this.name = "ApiError";
+  }
+}
+
+interface RequestParts {
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  form?: Record<string, unknown>;
+  multipart?: Record<string, unknown>;
+  body?: unknown;
+}
+
+async function request<T>(options: ClientOptions, method: string, path: string, init: RequestParts): Promise<T> {
+  const search = new URLSearchParams();
+  for (const [key, value] of Object.entries(init.query ?? {})) {
+    if (value === undefined || value === null) continue;
+    for (const item of Array.isArray(value) ? value : [value]) search.append(key, String(item));
+  }
+  const query = search.toString();
+  const url = options.baseUrl.replace(/\/$/, "") + path + (query ? "?" + query : "");
+
+  const base = typeof options.headers === "function" ? await options.headers() : options.headers;
+  const headers: Record<string, string> = { Accept: "application/json", ...base };
+  for (const [key, value] of Object.entries(init.headers ?? {})) {
+    if (value !== undefined && value !== null) headers[key] = String(value);
+  }
+
+  let body: BodyInit | undefined;
+  if (init.body !== undefined) {
+    headers["Content-Type"] = "application/json";
+    body = JSON.stringify(init.body);
+  } else if (init.form) {
+    // URLSearchParams sets Content-Type: application/x-www-form-urlencoded
+    const form = new URLSearchParams();
+    for (const [key, value] of Object.entries(init.form)) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) form.append(key, String(item));
+    }
+    body = form;
+  } else if (init.multipart) {
+    // FormData sets Content-Type: multipart/form-data with its boundary
+    const form = new FormData();
+    for (const [key, value] of Object.entries(init.multipart)) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) form.append(key, item instanceof Blob ? item : String(item));
+    }
+    body = form;
+  }
+
+  const response = await (options.fetch ?? fetch)(url, { method, headers, body });
+  const text = await response.text();
+  let data: unknown = text;
+  try {
+    data = text ? JSON.parse(text) : undefined;
+  } catch {
+    // Not JSON: keep the raw text
+  }
+  if (!response.ok) throw new ApiError(response.status, data);
+  return data as T;
+}
+
+`
@@ -0,0 +1,108 @@
+package swagger
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTypeScriptGolden(t *testing.T) {
+	spec, err := LoadSpecFile(filepath.Join("testdata", "client", "petstore.json"))
+	require.NoError(t, err)
+
+	code, err := GenerateTypeScript(spec, TypeScriptOptions{})
+	require.NoError(t, err)
+	assertGolden(t, filepath.Join("testdata", "typescript", "petstore.ts.golden"), code)
+	assert.NotRegexp(t, regexp.MustCompile(`[\w\]]\.\[`), string(code), "bracket access never follows a dot")
+	checkTypeScript(t, code)
+
+	for i := 0; i < 5; i++ {
+		again, err := GenerateTypeScript(spec, TypeScriptOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(again), "output is deterministic")
+	}
+}
+
+func TestGenerateTypeScriptTypes(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1"},
+		"paths": {},
+		"definitions": {
+			"Shape": {"oneOf": [{"$ref": "#/definitions/Circle"}, {"$ref": "#/definitions/Square"}]},
+			"Circle": {
+				"type": "object",
+				"required": ["kind", "radius"],
+				"properties": {"kind": {"type": "string", "enum": ["circle"]}, "radius": {"type": "number"}}
+			},
+			"Square": {
+				"type": "object",
+				"required": ["kind"],
+				"properties": {"kind": {"type": "string", "enum": ["square"]}, "size": {"type": "integer", "x-nullable": true}}
+			},
+			"Level": {"type": "integer", "enum": [1, 2, 3]},
+			"Grid": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}
+		}
+	}`
+
+	code, err := GenerateTypeScript(spec, TypeScriptOptions{SkipClient: true})
+	require.NoError(t, err)
+	output := string(code)
+
+	assert.Contains(t, output, "export type Shape = Circle | Square;")
+	assert.Contains(t, output, "export interface Circle {\n  kind: \"circle\";\n  radius: number;\n}")
+	assert.Contains(t, output, "size?: number | null;")
+	assert.Contains(t, output, "export type Level = 1 | 2 | 3;")
+	assert.Contains(t, output, `export type Grid = ("a" | "b")[];`)
+	assert.NotContains(t, output, "class")
+}
+
+func TestGenerateTypeScriptDiscriminator(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1"},
+		"paths": {},
+		"definitions": {
+			"Pet": {
+				"oneOf": [{"$ref": "#/definitions/Cat"}, {"$ref": "#/definitions/Dog"}, {"$ref": "#/definitions/Bird"}],
+				"discriminator": {"propertyName": "petType", "mapping": {"cat": "#/definitions/Cat"}}
+			},
+			"Shape": {"oneOf": [{"$ref": "#/definitions/Cat"}, {"type": "string"}], "discriminator": "petType"},
+			"Cat": {"type": "object", "required": ["petType"], "properties": {"petType": {"type": "string"}}},
+			"Dog": {"type": "object", "required": ["petType"], "properties": {"petType": {"type": "string", "enum": ["dog"]}}},
+			"Bird": {"type": "object", "required": ["petType"], "properties": {"petType": {"type": "string"}}}
+		}
+	}`
+
+	code, err := GenerateTypeScript(spec, TypeScriptOptions{SkipClient: true})
+	require.NoError(t, err)
+	output := string(code)
+
+	assert.Contains(t, output, `export type Pet = (Cat & { petType: "cat" }) | (Dog & { petType: "dog" }) | (Bird & { petType: "Bird" });`)
+	assert.Contains(t, output, `export type Shape = (Cat & { petType: "Cat" }) | string;`)
+	checkTypeScript(t, code)
+}
+
+// checkTypeScript compiles code with tsc, or runs it with a Node.js able to transform types,
+// skipping when neither is installed
+func checkTypeScript(t *testing.T, code []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "api.ts")
+	require.NoError(t, os.WriteFile(path, code, 0o644))
+
+	var cmd *exec.Cmd
+	if tsc, err := exec.LookPath("tsc"); err == nil {
+		cmd = exec.Command(tsc, "--noEmit", "--strict", "--target", "es2020", "--lib", "es2020,dom", path)
+	} else if node, err := exec.LookPath("node"); err == nil && exec.Command(node, "--experimental-transform-types", "-e", "").Run() == nil {
+		cmd = exec.Command(node, "--experimental-transform-types", "--no-warnings", path)
+	} else {
+		t.Skip("tsc or Node.js 22.7+ is needed to check the generated TypeScript")
+	}
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "generated TypeScript does not compile:\n%s", output)
+}
@@ -0,0 +1,457 @@
+package swagger
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// Generator produces realistic fake values for JSON schemas.
+// Generators created with the same seed produce the same values.
+type Generator struct {
+	spec        map[string]interface{}
+	rand        *rand.Rand
+	useExamples bool
+}
+
+// NewGenerator creates a Generator for the schemas of a spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+func NewGenerator(spec interface{}, seed int64) (*Generator, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{
+		spec:        specMap,
+		rand:        rand.New(rand.NewSource(seed)),
+		useExamples: true,
+	}, nil
+}
+
+// WithExamples selects whether documented example and default values are used (default: true)
+func (g *Generator) WithExamples(enabled bool) *Generator {
+	g.useExamples = enabled
+	return g
+}
+
+// Definition generates a value for a schema of the spec's definitions
+// (or components/schemas for OpenAPI 3.0)
+func (g *Generator) Definition(name string) (interface{}, error) {
+	ref := "#/definitions/" + escapePointerToken(name)
+	if isOpenAPI3(g.spec) {
+		ref = "#/components/schemas/" + escapePointerToken(name)
+	}
+	if _, err := ResolveRef(g.spec, ref); err != nil {
+		return nil, fmt.Errorf("unknown definition %q", name)
+	}
+	return g.Generate(map[string]interface{}{"$ref": ref}), nil
+}
+
+// Generate produces a value for a schema, which may reference the spec's definitions
+func (g *Generator) Generate(schema interface{}) interface{} {
+	return g.value(schema, "", map[string]bool{})
+}
+
+// SampleFor generates a reproducible fake value for a definition, e.g. in table-driven tests:
+//
+//	user, err := sw.SampleFor("User")
+//
+// Use NewGenerator to pick another seed.
+func (s *Swagger) SampleFor(name string) (interface{}, error) {
+	g, err := NewGenerator(s.spec, 1)
+	if err != nil {
+		return nil, err
+	}
+	return g.Definition(name)
+}
+
+// value generates a value for schema. name is the property name, used to pick realistic strings,
+// and expanding holds the refs being generated to stop on circular schemas.
+func (g *Generator) value(schema interface{}, name string, expanding map[string]bool) interface{} {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		if expanding[ref] {
+			return nil
+		}
+		target, err := ResolveRef(g.spec, ref)
+		if err != nil {
+			return nil
+		}
+		expanding[ref] = true
+		defer delete(expanding, ref)
+		return g.value(target, name, expanding)
+	}
+
+	if g.useExamples {
+		// Cloned so callers can modify the value without touching the spec
+		if example, ok := s["example"]; ok {
+			return cloneValue(example)
+		}
+		if value, ok := s["default"]; ok {
+			return cloneValue(value)
+		}
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[g.rand.Intn(len(enum))]
+	}
+	if allOf, ok := s["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, part := range allOf {
+			if object, ok := g.value(part, name, expanding).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if choices, ok := s[key].([]interface{}); ok && len(choices) > 0 {
+			return g.value(choices[g.rand.Intn(len(choices))], name, expanding)
+		}
+	}
+
+	typ, _ := s["type"].(string)
+	if typ == "" {
+		if _, ok := s["properties"]; ok {
+			typ = "object"
+		}
+	}
+	switch typ {
+	case "object":
+		return g.object(s, expanding)
+	case "array":
+		return g.array(s, name, expanding)
+	case "integer":
+		return g.integer(s)
+	case "number":
+		return g.number(s)
+	case "boolean":
+		return g.rand.Intn(2) == 1
+	case "string":
+		return g.string(s, name)
+	default:
+		return nil
+	}
+}
+
+// object generates every property; optional properties that would recurse into themselves are left out
+func (g *Generator) object(s map[string]interface{}, expanding map[string]bool) map[string]interface{} {
+	object := make(map[string]interface{})
+	properties, _ := s["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	for _, name := range stringSlice(s["required"]) {
+		required[name] = true
+	}
+	for _, name := range sortedKeys(properties) {
+		value := g.value(properties[name], name, expanding)
+		if value == nil && required[name] {
+			// A required circular reference can only end with an empty value
+			value = emptyValue(properties[name])
+		}
+		if value != nil {
+			object[name] = value
+		}
+	}
+	return object
+}
+
+// array generates between minItems and maxItems items (1 to 3 by default)
+func (g *Generator) array(s map[string]interface{}, name string, expanding map[string]bool) []interface{} {
+	minItems, maxItems := 1, 3
+	if n, ok := toFloat(s["minItems"]); ok {
+		minItems = int(n)
+		if maxItems < minItems {
+			maxItems = minItems
+		}
+	}
+	if n, ok := toFloat(s["maxItems"]); ok {
+		maxItems = int(n)
+		if minItems > maxItems {
+			minItems = maxItems
+		}
+	}
+	count := minItems + g.rand.Intn(maxItems-minItems+1)
+	unique := s["uniqueItems"] == true
+
+	items := make([]interface{}, 0, count)
+	for attempts := 0; len(items) < count && attempts < count*10; attempts++ {
+		item := g.value(s["items"], singular(name), expanding)
+		if item == nil {
+			break
+		}
+		if unique && containsValue(items, item) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// integer generates a whole number within minimum/maximum (0 to 1000 by default) honouring multipleOf
+func (g *Generator) integer(s map[string]interface{}) float64 {
+	low, high := numberBounds(s, 1)
+	low, high = math.Ceil(low), math.Floor(high)
+	if step, ok := toFloat(s["multipleOf"]); ok && step >= 1 {
+		first := math.Ceil(low/step) * step
+		steps := math.Floor((high - first) / step)
+		if steps < 0 {
+			return first
+		}
+		return first + g.upTo(steps)*step
+	}
+	if high < low {
+		return low
+	}
+	return math.Min(low+g.upTo(high-low), high)
+}
+
+// number generates a value with two decimals within minimum/maximum (0 to 1000 by default)
+func (g *Generator) number(s map[string]interface{}) float64 {
+	low, high := numberBounds(s, 0.01)
+	if step, ok := toFloat(s["multipleOf"]); ok && step > 0 {
+		first := math.Ceil(low/step) * step
+		steps := math.Floor((high - first) / step)
+		if steps < 0 {
+			return first
+		}
+		return first + g.upTo(steps)*step
+	}
+	// interpolating avoids overflowing high-low on bounds near ±MaxFloat64
+	f := g.rand.Float64()
+	value := math.Round((low*(1-f)+high*f)*100) / 100
+	return math.Min(math.Max(value, low), high)
+}
+
+// upTo draws a whole number between 0 and n. Spans too wide for Int63n, such as the whole
+// int64 or uint64 range, are drawn as a fraction of n; float64 cannot tell them apart anyway.
+func (g *Generator) upTo(n float64) float64 {
+	switch {
+	case !(n > 0) || math.IsInf(n, 1):
+		return 0
+	case n < math.MaxInt64:
+		return float64(g.rand.Int63n(int64(n) + 1))
+	default:
+		return math.Floor(g.rand.Float64() * n)
+	}
+}
+
+// numberBounds returns the inclusive range allowed by minimum/maximum, using delta for exclusive bounds
+func numberBounds(s map[string]interface{}, delta float64) (float64, float64) {
+	low, hasLow := toFloat(s["minimum"])
+	high, hasHigh := toFloat(s["maximum"])
+	if hasLow && s["exclusiveMinimum"] == true {
+		low += delta
+	}
+	if hasHigh && s["exclusiveMaximum"] == true {
+		high -= delta
+	}
+	switch {
+	case !hasLow && !hasHigh:
+		low, high = 0, 1000
+	case !hasLow:
+		low = math.Min(0, high-1000)
+	case !hasHigh:
+		high = math.Max(1000, low+1000)
+	}
+	return low, high
+}
+
+// string generates a value matching the format or pattern, picking realistic words from the property name
+func (g *Generator) string(s map[string]interface{}, name string) string {
+	var value string
+	format, _ := s["format"].(string)
+	if pattern, ok := s["pattern"].(string); ok {
+		value = g.fromPattern(pattern)
+	} else if value = g.formatted(format); value == "" {
+		value = g.word(name)
+	}
+
+	if format != "" || s["pattern"] != nil {
+		// Padding or truncating would break the format
+		return value
+	}
+	minLength, _ := toFloat(s["minLength"])
+	for len(value) < int(minLength) {
+		value += " " + g.pick(loremWords)
+	}
+	if maxLength, ok := toFloat(s["maxLength"]); ok && len(value) > int(maxLength) {
+		value = value[:int(maxLength)]
+	}
+	return value
+}
+
+// formatted generates a value for a string format, or "" for unknown formats
+func (g *Generator) formatted(format string) string {
+	switch format {
+	case "email":
+		return strings.ToLower(g.pick(firstNames)+"."+g.pick(lastNames)) + "@example.com"
+	case "uuid":
+		b := make([]byte, 16)
+		g.rand.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case "date-time":
+		return g.time().Format(time.RFC3339)
+	case "date":
+		return g.time().Format("2006-01-02")
+	case "uri", "url":
+		return "https://" + g.pick(domains) + "/" + g.pick(loremWords)
+	case "hostname":
+		return g.pick(domains)
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+g.rand.Intn(254))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.rand.Intn(0xfffe))
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(g.pick(loremWords)))
+	case "password":
+		return g.fromPattern(`[A-Za-z0-9]{12}`)
+	default:
+		return ""
+	}
+}
+
+// word picks a realistic string for a property name
+func (g *Generator) word(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "firstname"), strings.Contains(lower, "first_name"):
+		return g.pick(firstNames)
+	case strings.Contains(lower, "lastname"), strings.Contains(lower, "last_name"), strings.Contains(lower, "surname"):
+		return g.pick(lastNames)
+	case strings.Contains(lower, "email"):
+		return g.formatted("email")
+	case strings.Contains(lower, "name"):
+		return g.pick(firstNames) + " " + g.pick(lastNames)
+	case strings.Contains(lower, "city"):
+		return g.pick(cities)
+	case strings.Contains(lower, "phone"):
+		return g.fromPattern(`\+1 555-[0-9]{3}-[0-9]{4}`)
+	case strings.Contains(lower, "url"), strings.Contains(lower, "link"):
+		return g.formatted("uri")
+	case strings.Contains(lower, "description"), strings.Contains(lower, "message"), strings.Contains(lower, "comment"):
+		words := make([]string, 4+g.rand.Intn(5))
+		for i := range words {
+			words[i] = g.pick(loremWords)
+		}
+		sentence := strings.Join(words, " ")
+		return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+	default:
+		return g.pick(loremWords)
+	}
+}
+
+// time picks a moment in 2020-2025, truncated to seconds
+func (g *Generator) time() time.Time {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(g.rand.Int63n(6*365*24*3600)) * time.Second)
+}
+
+// pick returns a random element
+func (g *Generator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+// fromPattern generates a string matching a regular expression, or "" when it cannot be parsed
+func (g *Generator) fromPattern(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	g.writeRegexp(&b, re.Simplify())
+	return b.String()
+}
+
+// writeRegexp appends a random match of a parsed regular expression
+func (g *Generator) writeRegexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(byte('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.writeRegexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writeRegexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.writeRegexp(b, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			low, high = 0, 3
+		case syntax.OpPlus:
+			low, high = 1, 3
+		case syntax.OpQuest:
+			low, high = 0, 1
+		}
+		if high < 0 {
+			high = low + 3
+		}
+		for i := low + g.rand.Intn(high-low+1); i > 0; i-- {
+			g.writeRegexp(b, re.Sub[0])
+		}
+	}
+}
+
+// classRune picks a rune from a character class given as inclusive range pairs,
+// preferring printable ASCII so negated classes stay readable
+func (g *Generator) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := max(ranges[i], ' '+1); r <= min(ranges[i+1], '~'); r++ {
+			printable = append(printable, r)
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.rand.Intn(len(printable))]
+	}
+	if len(ranges) < 2 {
+		return 'x'
+	}
+	return ranges[0] + rune(g.rand.Intn(int(ranges[1]-ranges[0])+1))
+}
+
+// emptyValue returns the empty value of a schema's type, or nil when unknown
+func emptyValue(schema interface{}) interface{} {
+	s, _ := schema.(map[string]interface{})
+	switch s["type"] {
+	case "array":
+		return []interface{}{}
+	case "object":
+		return map[string]interface{}{}
+	}
+	if _, ok := s["$ref"]; ok {
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// singular turns a plural property name into the name of its items ("tags" -> "tag")
+func singular(name string) string {
+	if strings.HasSuffix(name, "s") && len(name) > 1 {
+		return name[:len(name)-1]
+	}
+	return name
+}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Radia", "Edsger"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Perlman", "Dijkstra"}
+	cities     = []string{"Istanbul", "Berlin", "Lisbon", "Toronto", "Tokyo", "Nairobi", "Austin", "Oslo"}
+	domains    = []string{"example.com", "example.org", "example.net"}
+	loremWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "tempor", "magna", "aliqua"}
+)
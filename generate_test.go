@@ -0,0 +1,173 @@
+package swagger
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatorSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"paths": {},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"required": ["id", "email", "tags"],
+			"properties": {
+				"id": {"type": "string", "format": "uuid"},
+				"email": {"type": "string", "format": "email"},
+				"name": {"type": "string", "minLength": 3, "maxLength": 40},
+				"age": {"type": "integer", "minimum": 18, "maximum": 99},
+				"score": {"type": "number", "minimum": 0, "maximum": 1, "exclusiveMaximum": true},
+				"level": {"type": "integer", "minimum": 1, "maximum": 100, "multipleOf": 5},
+				"createdAt": {"type": "string", "format": "date-time"},
+				"website": {"type": "string", "format": "uri"},
+				"code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{4}$"},
+				"status": {"type": "string", "enum": ["active", "banned"]},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 2, "maxItems": 4, "uniqueItems": true},
+				"address": {"$ref": "#/definitions/Address"},
+				"manager": {"$ref": "#/definitions/User"}
+			}
+		},
+		"Address": {
+			"type": "object",
+			"required": ["city"],
+			"properties": {
+				"city": {"type": "string"},
+				"zip": {"type": "string", "pattern": "\\d{5}"}
+			}
+		},
+		"Node": {
+			"type": "object",
+			"required": ["children"],
+			"properties": {
+				"children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}
+			}
+		},
+		"Documented": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "example": "Jane"},
+				"limit": {"type": "integer", "default": 20}
+			}
+		}
+	}
+}`
+
+func TestGeneratorProducesValidValues(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		g, err := NewGenerator(generatorSpec, seed)
+		require.NoError(t, err)
+
+		for _, name := range []string{"User", "Address", "Node"} {
+			value, err := g.Definition(name)
+			require.NoError(t, err)
+
+			schema := map[string]interface{}{"$ref": "#/definitions/" + name}
+			assert.Empty(t, validateValue(g.spec, schema, value, ""), "seed %d, %s: %v", seed, name, value)
+		}
+	}
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	first, err := NewGenerator(generatorSpec, 42)
+	require.NoError(t, err)
+	second, err := NewGenerator(generatorSpec, 42)
+	require.NoError(t, err)
+	other, err := NewGenerator(generatorSpec, 7)
+	require.NoError(t, err)
+
+	a, _ := first.Definition("User")
+	b, _ := second.Definition("User")
+	c, _ := other.Definition("User")
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestGeneratorValues(t *testing.T) {
+	g, err := NewGenerator(generatorSpec, 1)
+	require.NoError(t, err)
+	value, err := g.Definition("User")
+	require.NoError(t, err)
+	user := value.(map[string]interface{})
+
+	tests := []struct {
+		field   string
+		pattern string
+	}{
+		{"id", `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{"email", `^[a-z]+\.[a-z]+@example\.com$`},
+		{"createdAt", `^20\d\d-\d\d-\d\dT\d\d:\d\d:\d\dZ$`},
+		{"website", `^https://`},
+		{"code", `^[A-Z]{3}-[0-9]{4}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Regexp(t, regexp.MustCompile(tt.pattern), user[tt.field])
+		})
+	}
+
+	assert.NotContains(t, user, "manager", "circular optional properties are left out")
+}
+
+func TestGeneratorWideBounds(t *testing.T) {
+	g, err := NewGenerator(generatorSpec, 1)
+	require.NoError(t, err)
+
+	schemas := []string{
+		`{"type": "integer", "format": "int64", "minimum": 0, "maximum": 9223372036854775807}`,
+		`{"type": "integer", "format": "int64", "minimum": -9223372036854775808, "maximum": 9223372036854775807}`,
+		`{"type": "integer", "format": "uint64", "minimum": 0, "maximum": 18446744073709551615}`,
+		`{"type": "integer", "minimum": -9223372036854775808, "maximum": 9223372036854775807, "multipleOf": 2}`,
+		`{"type": "number", "minimum": -1.7e308, "maximum": 1.7e308}`,
+		`{"type": "number", "minimum": 0, "maximum": 1e300, "multipleOf": 0.5}`,
+	}
+	for _, raw := range schemas {
+		t.Run(raw, func(t *testing.T) {
+			var schema map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(raw), &schema))
+			for i := 0; i < 20; i++ {
+				var value interface{}
+				require.NotPanics(t, func() { value = g.Generate(schema) })
+				assert.Empty(t, validateValue(nil, schema, value, ""), "value %v", value)
+			}
+		})
+	}
+}
+
+func TestGeneratorExamples(t *testing.T) {
+	g, err := NewGenerator(generatorSpec, 1)
+	require.NoError(t, err)
+	value, _ := g.Definition("Documented")
+	assert.Equal(t, map[string]interface{}{"name": "Jane", "limit": float64(20)}, value)
+
+	value, _ = g.WithExamples(false).Definition("Documented")
+	assert.NotEqual(t, "Jane", value.(map[string]interface{})["name"])
+}
+
+func TestSampleFor(t *testing.T) {
+	sw := New(nil)
+	sw.SetDefinitions(map[string]interface{}{
+		"Pet": map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"name"},
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "string"},
+			},
+		},
+	})
+
+	first, err := sw.SampleFor("Pet")
+	require.NoError(t, err)
+	second, err := sw.SampleFor("Pet")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.NotEmpty(t, first.(map[string]interface{})["name"])
+
+	_, err = sw.SampleFor("Missing")
+	assert.EqualError(t, err, `unknown definition "Missing"`)
+}
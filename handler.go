@@ -0,0 +1,281 @@
+package swagger
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// Route is a path served by a Handler. Prefix routes also serve every path below Path+"/".
+type Route struct {
+	Path   string
+	Prefix bool
+}
+
+// Handler serves the spec, the Postman and Insomnia exports and Swagger UI over net/http.
+// Setup and friends register it on gin; the packages under adapters/ register the same
+// handler on chi, echo, fiber and http.ServeMux, so every framework behaves identically.
+type Handler struct {
+	config  *Config
+	profile *Profile
+	spec    func() interface{}
+	version func() int64
+	events  http.Handler
+	assets  http.Handler
+	page    uiPage
+	mock    http.Handler
+}
+
+// NewHandler validates config and prepares a Handler for a spec, which is one of:
+//   - *Swagger, serving its paths and definitions as they are set
+//   - *Aggregator, serving the current merged spec
+//   - *SpecWatcher, serving the current file and reload events at UIPath/events
+//   - anything else accepted as a spec (*SwaggerSpec, swag spec from LoadSwagDocs or raw JSON);
+//     it is validated first when config.FailOnInvalidSpec is set
+//
+// A disabled config yields a Handler without routes.
+func NewHandler(spec interface{}, config *Config) (*Handler, error) {
+	config, err := prepareConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	h := &Handler{
+		config:  config,
+		profile: config.ActiveProfile(),
+		assets:  http.StripPrefix(config.UIPath, http.FileServer(swaggerFiles.HTTP)),
+		page: uiPage{
+			Title:    config.Title + " - Swagger UI",
+			URL:      config.JSONPath,
+			TryItOut: true,
+		},
+	}
+	if h.profile != nil {
+		h.page.TryItOut = h.profile.TryItOut
+	}
+
+	switch source := spec.(type) {
+	case *Swagger:
+		h.spec = func() interface{} { return source.spec }
+		h.version = source.version.Load
+	case *Aggregator:
+		h.spec = func() interface{} { return source.Spec() }
+		h.version = source.specVersion
+	case *SpecWatcher:
+		h.spec = func() interface{} { return source.Spec() }
+		h.version = source.version.Load
+		h.events = source.Events()
+		h.page.Events = config.UIPath + "/events"
+	default:
+		h.spec = func() interface{} { return spec }
+		h.version = func() int64 { return 0 }
+		if config.Enabled && config.FailOnInvalidSpec {
+			if err := ValidateSpec(spec); err != nil {
+				return nil, fmt.Errorf("invalid swagger spec: %w", err)
+			}
+		}
+	}
+
+	if !config.Enabled {
+		return h, nil
+	}
+	if h.profile != nil && h.profile.Mock {
+		// mockHandler is a gin handler; a private engine runs it for any framework
+		engine := gin.New()
+		engine.NoRoute(profileMockHandler(h.spec, h.version))
+		h.mock = engine
+	}
+	config.logger().Print(startupSummary(config, h.events != nil))
+	return h, nil
+}
+
+// Routes lists the paths to register for the Handler, none when the config is disabled
+func (h *Handler) Routes() []Route {
+	if !h.config.Enabled {
+		return nil
+	}
+	routes := []Route{{Path: h.config.JSONPath}}
+	if h.config.PostmanPath != "" {
+		routes = append(routes, Route{Path: h.config.PostmanPath})
+	}
+	if h.config.InsomniaPath != "" {
+		routes = append(routes, Route{Path: h.config.InsomniaPath})
+	}
+	return append(routes, Route{Path: h.config.UIPath, Prefix: true})
+}
+
+// NotFound returns the handler for requests no route matched: mock responses for documented
+// operations when the active profile enables mock mode, otherwise nil
+func (h *Handler) NotFound() http.Handler {
+	return h.mock
+}
+
+// Config returns the validated config the Handler serves with
+func (h *Handler) Config() *Config {
+	return h.config
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Enabled {
+		http.NotFound(w, r)
+		return
+	}
+	if !h.authorized(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ui := h.config.UIPath
+	switch path := r.URL.Path; {
+	case path == h.config.JSONPath:
+		h.serveSpec(w, r)
+	case h.config.PostmanPath != "" && path == h.config.PostmanPath:
+		h.serveCollection(w, r, PostmanCollection)
+	case h.config.InsomniaPath != "" && path == h.config.InsomniaPath:
+		h.serveCollection(w, r, InsomniaExport)
+	case path == ui || path == ui+"/":
+		http.Redirect(w, r, ui+"/index.html", http.StatusMovedPermanently)
+	case path == ui+"/index.html":
+		h.serveIndex(w)
+	case path == ui+"/events" && h.events != nil:
+		h.events.ServeHTTP(w, r)
+	case strings.HasPrefix(path, ui+"/"):
+		h.assets.ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// authorized checks the basic auth credentials required by the active profile,
+// answering 401 when they are missing or wrong
+func (h *Handler) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if h.profile == nil || len(h.profile.Auth) == 0 {
+		return true
+	}
+	if user, password, ok := r.BasicAuth(); ok {
+		if expected, found := h.profile.Auth[user]; found && subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1 {
+			return true
+		}
+	}
+	w.Header().Set("WWW-Authenticate", `Basic realm="Authorization Required"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	return false
+}
+
+// servedSpec returns a copy of the spec with host and scheme detected from the request
+func (h *Handler) servedSpec(r *http.Request) (map[string]interface{}, error) {
+	specMap, err := toSpecMap(h.spec())
+	if err != nil {
+		return nil, fmt.Errorf("invalid swagger spec: %w", err)
+	}
+	dynamicSpec := shallowCopy(specMap)
+	applyDetectedHost(dynamicSpec, r, h.config)
+	return dynamicSpec, nil
+}
+
+// serveSpec serves the spec with recorded examples, code samples and the active profile applied
+func (h *Handler) serveSpec(w http.ResponseWriter, r *http.Request) {
+	dynamicSpec, err := h.servedSpec(r)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	spec, err := decorateSpec(dynamicSpec, h.config)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, spec)
+}
+
+// serveCollection serves the spec converted by export, e.g. PostmanCollection
+func (h *Handler) serveCollection(w http.ResponseWriter, r *http.Request, export func(interface{}) (map[string]interface{}, error)) {
+	dynamicSpec, err := h.servedSpec(r)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	collection, err := export(dynamicSpec)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, collection)
+}
+
+// serveIndex renders the Swagger UI page
+func (h *Handler) serveIndex(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = uiIndexTemplate.Execute(w, h.page)
+}
+
+// writeJSON writes value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// SetupHandler registers a Handler on a gin router: its routes for GET and HEAD, and the NoRoute
+// handler when mock mode is on. Mock mode takes over NoRoute, replacing one already set on the
+// router, so keep mock mode off where you need your own. Route conflicts, which gin reports by
+// panicking, are returned as errors before anything is registered.
+func SetupHandler(router *gin.Engine, h *Handler) error {
+	var routes []gin.RouteInfo
+	for _, route := range h.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*any"
+		}
+		routes = append(routes,
+			gin.RouteInfo{Method: http.MethodGet, Path: path},
+			gin.RouteInfo{Method: http.MethodHead, Path: path})
+	}
+	if err := checkRoutes(router, routes); err != nil {
+		return fmt.Errorf("failed to register swagger routes: %w", err)
+	}
+
+	handler := gin.WrapH(h)
+	for _, route := range routes {
+		router.Handle(route.Method, route.Path, handler)
+	}
+	if notFound := h.NotFound(); notFound != nil {
+		router.NoRoute(gin.WrapH(notFound))
+	}
+	return nil
+}
+
+// checkRoutes adds routes to a scratch engine holding the router's routes, so that a conflict
+// is found without leaving part of them registered on the router
+func checkRoutes(router *gin.Engine, routes []gin.RouteInfo) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	noop := func(*gin.Context) {}
+	scratch := gin.New()
+	for _, route := range router.Routes() {
+		scratch.Handle(route.Method, route.Path, noop)
+	}
+	for _, route := range routes {
+		scratch.Handle(route.Method, route.Path, noop)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package swagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHandler(t *testing.T) {
+	t.Run("routes", func(t *testing.T) {
+		handler, err := NewHandler(New(nil), quietConfig().WithPostmanPath("/postman.json"))
+		require.NoError(t, err)
+		assert.Equal(t, []Route{
+			{Path: "/swagger.json"},
+			{Path: "/postman.json"},
+			{Path: "/swagger", Prefix: true},
+		}, handler.Routes())
+		assert.Nil(t, handler.NotFound())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		handler, err := NewHandler(`{"swagger": "2.0"}`, quietConfig().WithEnabled(false).WithFailOnInvalidSpec(true))
+		require.NoError(t, err, "disabled configs skip spec validation")
+		assert.Empty(t, handler.Routes())
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		_, err := NewHandler(`{"swagger": "2.0"}`, quietConfig().WithFailOnInvalidSpec(true))
+		assert.ErrorContains(t, err, "invalid swagger spec")
+	})
+
+	t.Run("mock mode", func(t *testing.T) {
+		config := quietConfig().WithEnvironment("demo").WithProfile(Profile{Name: "demo", Enabled: Bool(true), Mock: true})
+		handler, err := NewHandler(profileSpec, config)
+		require.NoError(t, err)
+		assert.NotNil(t, handler.NotFound())
+	})
+}
+
+func TestHandlerServeHTTP(t *testing.T) {
+	handler, err := NewHandler(profileSpec, quietConfig())
+	require.NoError(t, err)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := serve(http.MethodGet, "/swagger.json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = serve(http.MethodPost, "/swagger.json")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+
+	assert.Equal(t, http.StatusMovedPermanently, serve(http.MethodGet, "/swagger").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/swagger/swagger-ui-bundle.js").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/swagger/events").Code, "events are only served for a SpecWatcher")
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/users").Code)
+}
@@ -0,0 +1,538 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxEnumValues is the largest number of distinct strings inferred as an enum
+	maxEnumValues = 5
+
+	// minEnumSamples is the number of observations needed before strings are inferred as an enum
+	minEnumSamples = 4
+)
+
+// DraftOperation is an operation proposed for a route the spec does not document
+type DraftOperation struct {
+	// Method is the upper-case HTTP method
+	Method string `json:"method"`
+
+	// Path is the path template relative to the spec's basePath
+	Path string `json:"path"`
+
+	// Samples is the number of recorded examples the operation was inferred from,
+	// 0 when it only comes from route discovery
+	Samples int `json:"samples"`
+
+	// Operation is the proposed Swagger 2.0 operation object
+	Operation map[string]interface{} `json:"operation"`
+}
+
+// PatchOperation is a JSON Patch (RFC 6902) operation
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// Proposal holds draft operations for undocumented routes and the JSON Patch adding them to the spec
+type Proposal struct {
+	Operations []DraftOperation `json:"operations"`
+	Patch      []PatchOperation `json:"patch"`
+}
+
+// InferSchema infers a Swagger 2.0 schema from observed JSON values (as decoded by encoding/json).
+// Observations are merged: object fields missing from some values are optional, numbers get the
+// observed range, repeated strings from a small set become an enum, and strings that all share a
+// format (date-time, date, email, uuid, uri) get it. Mixed types are listed in x-types, and null
+// values make the schema x-nullable.
+func InferSchema(values ...interface{}) map[string]interface{} {
+	byKind := make(map[string][]interface{})
+	nullable := false
+	for _, value := range values {
+		kind := jsonKind(value)
+		if kind == "null" {
+			nullable = true
+			continue
+		}
+		byKind[kind] = append(byKind[kind], value)
+	}
+	if integers, ok := byKind["integer"]; ok && len(byKind["number"]) > 0 {
+		byKind["number"] = append(byKind["number"], integers...)
+		delete(byKind, "integer")
+	}
+
+	var schema map[string]interface{}
+	switch len(byKind) {
+	case 0:
+		schema = map[string]interface{}{}
+	case 1:
+		for kind, observed := range byKind {
+			schema = inferKind(kind, observed)
+		}
+	default:
+		kinds := make([]string, 0, len(byKind))
+		for kind := range byKind {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		schema = map[string]interface{}{"x-types": kinds}
+	}
+	if nullable {
+		schema["x-nullable"] = true
+	}
+	return schema
+}
+
+// jsonKind returns the JSON Schema type of a decoded value, telling integers from numbers
+func jsonKind(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+// inferKind infers the schema of values of the same kind
+func inferKind(kind string, values []interface{}) map[string]interface{} {
+	schema := map[string]interface{}{"type": kind}
+	switch kind {
+	case "integer", "number":
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, value := range values {
+			n := value.(float64)
+			low, high = math.Min(low, n), math.Max(high, n)
+		}
+		schema["minimum"], schema["maximum"] = low, high
+	case "string":
+		strs := make([]string, 0, len(values))
+		for _, value := range values {
+			// Redacted values say nothing about the real ones
+			if s := value.(string); s != redactedValue {
+				strs = append(strs, s)
+			}
+		}
+		if format := stringFormat(strs); format != "" {
+			schema["format"] = format
+		} else if enum := stringEnum(strs); enum != nil {
+			schema["enum"] = enum
+		}
+	case "array":
+		var items []interface{}
+		for _, value := range values {
+			items = append(items, value.([]interface{})...)
+		}
+		schema["items"] = InferSchema(items...)
+	case "object":
+		fields := make(map[string][]interface{})
+		for _, value := range values {
+			for key, field := range value.(map[string]interface{}) {
+				fields[key] = append(fields[key], field)
+			}
+		}
+		properties := make(map[string]interface{}, len(fields))
+		var required []interface{}
+		for _, key := range sortedKeys(toAnyMap(fields)) {
+			properties[key] = InferSchema(fields[key]...)
+			if len(fields[key]) == len(values) {
+				required = append(required, key)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+	return schema
+}
+
+// stringFormat returns the format shared by every string, empty if there is none
+func stringFormat(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	checks := []struct {
+		format string
+		match  func(string) bool
+	}{
+		{"date-time", func(s string) bool { _, err := time.Parse(time.RFC3339, s); return err == nil }},
+		{"date", func(s string) bool { _, err := time.Parse("2006-01-02", s); return err == nil }},
+		{"uuid", uuidPattern.MatchString},
+		{"email", func(s string) bool { a, err := mail.ParseAddress(s); return err == nil && a.Address == s }},
+		{"uri", func(s string) bool {
+			u, err := url.Parse(s)
+			return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+		}},
+	}
+	for _, check := range checks {
+		all := true
+		for _, value := range values {
+			if !check.match(value) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return check.format
+		}
+	}
+	return ""
+}
+
+// stringEnum returns the sorted distinct values when a few values repeat, nil otherwise
+func stringEnum(values []string) []interface{} {
+	if len(values) < minEnumSamples {
+		return nil
+	}
+	distinct := make(map[string]bool)
+	for _, value := range values {
+		distinct[value] = true
+	}
+	if len(distinct) > maxEnumValues || len(distinct)*2 > len(values) {
+		return nil
+	}
+	enum := make([]interface{}, 0, len(distinct))
+	for _, value := range sortedKeys(toAnyMap(distinct)) {
+		enum = append(enum, value)
+	}
+	return enum
+}
+
+// toAnyMap converts a map for sortedKeys
+func toAnyMap[V any](m map[string]V) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		out[key] = value
+	}
+	return out
+}
+
+// inferParameter infers the type of a path, query or header parameter from its raw values
+func inferParameter(values []string) map[string]interface{} {
+	observed := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if value == redactedValue {
+			continue
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			observed = append(observed, n)
+		} else if value == "true" || value == "false" {
+			observed = append(observed, value == "true")
+		} else {
+			observed = append(observed, value)
+		}
+	}
+	schema := InferSchema(observed...)
+	param := map[string]interface{}{"type": "string"}
+	if typ, ok := schema["type"].(string); ok {
+		param["type"] = typ
+	}
+	for _, key := range []string{"format", "enum"} {
+		if value, ok := schema[key]; ok {
+			param[key] = value
+		}
+	}
+	return param
+}
+
+// inferOperation builds a draft operation from the recorded examples of one route.
+// The examples' paths include the basePath, which is stripped from the draft.
+func inferOperation(method, path, basePath string, examples []RecordedExample) DraftOperation {
+	draft := discoveredOperation(method, path, basePath)
+	draft.Samples = len(examples)
+	op := draft.Operation
+	op["summary"] = fmt.Sprintf("Inferred from %d recorded request(s)", len(examples))
+
+	// Path parameters take their values from the request path segments
+	template := strings.Split(basePath+draft.Path, "/")
+	var params []interface{}
+	pathParams, _ := op["parameters"].([]interface{})
+	for _, param := range pathParams {
+		p := param.(map[string]interface{})
+		var values []string
+		for _, example := range examples {
+			segments := strings.Split(example.RequestPath, "/")
+			for i, segment := range template {
+				if segment == "{"+p["name"].(string)+"}" && i < len(segments) {
+					values = append(values, segments[i])
+				}
+			}
+		}
+		for key, value := range inferParameter(values) {
+			p[key] = value
+		}
+		params = append(params, p)
+	}
+
+	queries := make(map[string][]string)
+	for _, example := range examples {
+		query, _ := url.ParseQuery(example.Query)
+		for name, values := range query {
+			if len(values) > 0 {
+				queries[name] = append(queries[name], values[0])
+			}
+		}
+	}
+	for _, name := range sortedKeys(toAnyMap(queries)) {
+		param := inferParameter(queries[name])
+		param["name"], param["in"] = name, "query"
+		if len(queries[name]) == len(examples) {
+			param["required"] = true
+		}
+		params = append(params, param)
+	}
+
+	var bodies []interface{}
+	for _, example := range examples {
+		if example.RequestBody != nil {
+			bodies = append(bodies, example.RequestBody)
+		}
+	}
+	if len(bodies) > 0 {
+		params = append(params, map[string]interface{}{
+			"name":     "body",
+			"in":       "body",
+			"required": len(bodies) == len(examples),
+			"schema":   InferSchema(bodies...),
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	} else {
+		delete(op, "parameters")
+	}
+
+	byStatus := make(map[string][]interface{})
+	for _, example := range examples {
+		status := strconv.Itoa(example.Status)
+		byStatus[status] = append(byStatus[status], example.ResponseBody)
+	}
+	responses := make(map[string]interface{}, len(byStatus))
+	for status, observed := range byStatus {
+		code, _ := strconv.Atoi(status)
+		response := map[string]interface{}{"description": http.StatusText(code)}
+		var values []interface{}
+		for _, value := range observed {
+			if value != nil {
+				values = append(values, value)
+			}
+		}
+		if len(values) > 0 {
+			response["schema"] = InferSchema(values...)
+		}
+		responses[status] = response
+	}
+	op["responses"] = responses
+	return draft
+}
+
+// discoveredOperation builds a skeleton operation for a route with only its path parameters.
+// The path may be a gin path ("/users/:id") or a spec path, and includes the basePath.
+func discoveredOperation(method, path, basePath string) DraftOperation {
+	path = strings.TrimPrefix(ginPathToSpec(path), basePath)
+	if path == "" {
+		path = "/"
+	}
+	params := []interface{}{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"type":     "string",
+		})
+	}
+	op := map[string]interface{}{
+		"summary":   "Discovered route",
+		"responses": map[string]interface{}{"200": map[string]interface{}{"description": "OK"}},
+		"x-draft":   true,
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	return DraftOperation{Method: strings.ToUpper(method), Path: path, Operation: op}
+}
+
+// Propose drafts operations for the routes the spec does not document. Routes with recorded
+// traffic get schemas inferred from it; routes only known from discovery (router.Routes())
+// get a skeleton with their path parameters. Routes excluded by SkipPaths are left out.
+//
+// Example:
+//
+//	proposal, err := recorder.Propose(swagSpec, router.Routes())
+func (r *Recorder) Propose(spec interface{}, routes gin.RoutesInfo) (*Proposal, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("proposals support Swagger 2.0 specs only")
+	}
+	basePath, _ := specMap["basePath"].(string)
+	basePath = strings.TrimSuffix(basePath, "/")
+
+	documented := make(map[string]bool)
+	for _, op := range specOperations(specMap) {
+		documented[operationKey(op)] = true
+	}
+	routeKey := func(method, path string) string {
+		relative := strings.TrimPrefix(ginPathToSpec(path), basePath)
+		return strings.ToLower(method) + " " + pathParamPattern.ReplaceAllString(relative, "{}")
+	}
+
+	// Group the recorded examples of undocumented routes
+	recorded := make(map[string][]RecordedExample)
+	var order []string
+	for _, example := range r.Examples() {
+		key := routeKey(example.Method, example.Path)
+		if documented[key] {
+			continue
+		}
+		if _, ok := recorded[key]; !ok {
+			order = append(order, key)
+		}
+		recorded[key] = append(recorded[key], example)
+	}
+
+	drafts := make(map[string]DraftOperation)
+	for _, key := range order {
+		examples := recorded[key]
+		drafts[key] = inferOperation(examples[0].Method, examples[0].Path, basePath, examples)
+	}
+	for _, route := range routes {
+		key := routeKey(route.Method, route.Path)
+		if _, ok := drafts[key]; ok || documented[key] || r.skips(route.Path) {
+			continue
+		}
+		drafts[key] = discoveredOperation(route.Method, route.Path, basePath)
+	}
+
+	proposal := &Proposal{Operations: []DraftOperation{}, Patch: []PatchOperation{}}
+	for _, draft := range drafts {
+		proposal.Operations = append(proposal.Operations, draft)
+	}
+	sort.Slice(proposal.Operations, func(i, j int) bool {
+		a, b := proposal.Operations[i], proposal.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	proposal.Patch = proposalPatch(specMap, proposal.Operations)
+	return proposal, nil
+}
+
+// proposalPatch writes the JSON Patch adding draft operations. Drafts whose path matches an existing
+// path item up to parameter names are added to it, with their path parameters renamed to match.
+func proposalPatch(spec map[string]interface{}, drafts []DraftOperation) []PatchOperation {
+	paths, _ := spec["paths"].(map[string]interface{})
+	existing := make(map[string]string)
+	for path := range paths {
+		existing[pathParamPattern.ReplaceAllString(path, "{}")] = path
+	}
+
+	patch := []PatchOperation{}
+	if paths == nil {
+		patch = append(patch, PatchOperation{Op: "add", Path: "/paths", Value: map[string]interface{}{}})
+	}
+	for _, draft := range drafts {
+		path, operation := draft.Path, draft.Operation
+		normalized := pathParamPattern.ReplaceAllString(path, "{}")
+		if target, ok := existing[normalized]; ok && target != path {
+			// the draft keeps its own names; only the patch follows the existing path
+			operation = cloneValue(operation).(map[string]interface{})
+			renameParameters(operation, path, target)
+			path = target
+		} else if !ok {
+			existing[normalized] = path
+			patch = append(patch, PatchOperation{Op: "add", Path: "/paths/" + escapePointerToken(path), Value: map[string]interface{}{}})
+		}
+		patch = append(patch, PatchOperation{
+			Op:    "add",
+			Path:  "/paths/" + escapePointerToken(path) + "/" + strings.ToLower(draft.Method),
+			Value: operation,
+		})
+	}
+	return patch
+}
+
+// renameParameters renames the path parameters of an operation from one template's names to another's
+func renameParameters(op map[string]interface{}, from, to string) {
+	fromNames := pathParamPattern.FindAllStringSubmatch(from, -1)
+	toNames := pathParamPattern.FindAllStringSubmatch(to, -1)
+	renamed := make(map[string]string)
+	for i := range fromNames {
+		if i < len(toNames) {
+			renamed[fromNames[i][1]] = toNames[i][1]
+		}
+	}
+	params, _ := op["parameters"].([]interface{})
+	for _, param := range params {
+		p, _ := param.(map[string]interface{})
+		if name, ok := renamed[fmt.Sprint(p["name"])]; ok && p["in"] == "path" {
+			p["name"] = name
+		}
+	}
+}
+
+// ProposalHandler serves the proposal for the router's undocumented routes as JSON, or only
+// the JSON Patch with ?format=patch so it can be saved and reviewed.
+//
+// Example:
+//
+//	router.GET("/debug/swagger/proposal", recorder.ProposalHandler(router, swagSpec))
+func (r *Recorder) ProposalHandler(router *gin.Engine, spec interface{}) gin.HandlerFunc {
+	return gin.WrapH(r.ProposalHTTPHandler(spec, router.Routes))
+}
+
+// ProposalHTTPHandler is ProposalHandler for other routers: routes is called on every request
+// to discover the registered routes, e.g. with swaggerchi.Routes or swaggerhttp.Mux.Routes.
+//
+// Example:
+//
+//	mux.Handle("GET /debug/swagger/proposal", recorder.ProposalHTTPHandler(swagSpec, mux.Routes))
+func (r *Recorder) ProposalHTTPHandler(spec interface{}, routes func() gin.RoutesInfo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		proposal, err := r.Propose(spec, routes())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		var value interface{} = proposal
+		if req.URL.Query().Get("format") == "patch" {
+			value = proposal.Patch
+		}
+		data, err := json.MarshalIndent(value, "", "    ")
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(data)
+	})
+}
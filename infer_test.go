@@ -0,0 +1,143 @@
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeAll(t *testing.T, documents ...string) []interface{} {
+	t.Helper()
+	values := make([]interface{}, len(documents))
+	for i, document := range documents {
+		require.NoError(t, json.Unmarshal([]byte(document), &values[i]))
+	}
+	return values
+}
+
+func TestInferSchema(t *testing.T) {
+	values := decodeAll(t,
+		`{"id": 1, "status": "active", "score": 1.5, "createdAt": "2024-01-02T10:00:00Z", "tags": ["a"], "manager": null}`,
+		`{"id": 7, "status": "banned", "score": 3, "createdAt": "2024-03-04T10:00:00Z", "tags": [], "manager": {"id": 2}}`,
+		`{"id": 3, "status": "active", "score": 2, "createdAt": "2024-05-06T10:00:00Z", "nickname": "jj"}`,
+		`{"id": 4, "status": "active", "score": 2, "createdAt": "2024-05-06T10:00:00Z", "email": "[REDACTED]"}`,
+	)
+
+	schema := InferSchema(values...)
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []interface{}{"createdAt", "id", "score", "status"}, schema["required"], "fields missing from some values are optional")
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer", "minimum": float64(1), "maximum": float64(7)}, properties["id"])
+	assert.Equal(t, map[string]interface{}{"type": "number", "minimum": 1.5, "maximum": float64(3)}, properties["score"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"active", "banned"}}, properties["status"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["createdAt"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["nickname"], "a single value is no enum")
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["email"], "redacted values are not inspected")
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}, properties["tags"])
+
+	manager := properties["manager"].(map[string]interface{})
+	assert.Equal(t, true, manager["x-nullable"])
+	assert.Equal(t, "object", manager["type"])
+
+	assert.Equal(t, map[string]interface{}{"x-types": []string{"integer", "string"}}, InferSchema(decodeAll(t, `1`, `"a"`)...))
+}
+
+func TestInferParameter(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, inferParameter([]string{"1", "42"}))
+	assert.Equal(t, map[string]interface{}{"type": "boolean"}, inferParameter([]string{"true", "false"}))
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "uuid"}, inferParameter([]string{"3fa85f64-5717-4562-b3fc-2c963f66afa6"}))
+	assert.Equal(t, map[string]interface{}{"type": "string"}, inferParameter([]string{redactedValue}))
+}
+
+func TestPropose(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder, err := NewRecorder(RecorderOptions{})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(recorder.Middleware())
+	router.GET("/api/users/:id", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": c.Param("id")}) })
+	router.DELETE("/api/users/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/api/legacy/orders/:orderId/items", func(c *gin.Context) {
+		var body map[string]interface{}
+		_ = c.ShouldBindJSON(&body)
+		c.JSON(http.StatusCreated, gin.H{"itemId": 9, "quantity": body["quantity"]})
+	})
+	router.GET("/api/legacy/report", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	for _, body := range []string{`{"sku": "A-1", "quantity": 2}`, `{"sku": "B-7", "quantity": 1, "note": "gift"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/legacy/orders/15/items?dryRun=true", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"basePath": "/api",
+		"paths": {
+			"/users/{userId}": {"get": {"responses": {"200": {"description": "OK"}}}}
+		}
+	}`
+	proposal, err := recorder.Propose(spec, router.Routes())
+	require.NoError(t, err)
+
+	require.Len(t, proposal.Operations, 3, "documented routes are left out")
+	assert.Equal(t, "/legacy/orders/{orderId}/items", proposal.Operations[0].Path)
+	assert.Equal(t, "/legacy/report", proposal.Operations[1].Path)
+	assert.Equal(t, 0, proposal.Operations[1].Samples, "discovered only")
+	assert.Equal(t, "/users/{id}", proposal.Operations[2].Path)
+	assert.Equal(t, "DELETE", proposal.Operations[2].Method)
+
+	inferred := proposal.Operations[0]
+	assert.Equal(t, 2, inferred.Samples)
+	params := inferred.Operation["parameters"].([]interface{})
+	require.Len(t, params, 3)
+	assert.Equal(t, map[string]interface{}{"name": "orderId", "in": "path", "required": true, "type": "integer"}, params[0])
+	assert.Equal(t, map[string]interface{}{"name": "dryRun", "in": "query", "required": true, "type": "boolean"}, params[1])
+	body := params[2].(map[string]interface{})
+	assert.Equal(t, []interface{}{"quantity", "sku"}, body["schema"].(map[string]interface{})["required"])
+	response := inferred.Operation["responses"].(map[string]interface{})["201"].(map[string]interface{})
+	assert.Equal(t, "Created", response["description"])
+	assert.Contains(t, response["schema"].(map[string]interface{})["properties"], "itemId")
+
+	assert.Equal(t, []PatchOperation{
+		{Op: "add", Path: "/paths/~1legacy~1orders~1{orderId}~1items", Value: map[string]interface{}{}},
+		{Op: "add", Path: "/paths/~1legacy~1orders~1{orderId}~1items/post", Value: proposal.Operations[0].Operation},
+		{Op: "add", Path: "/paths/~1legacy~1report", Value: map[string]interface{}{}},
+		{Op: "add", Path: "/paths/~1legacy~1report/get", Value: proposal.Operations[1].Operation},
+	}, proposal.Patch[:4])
+	renamed := proposal.Patch[4]
+	assert.Equal(t, "/paths/~1users~1{userId}/delete", renamed.Path)
+	patchParams := renamed.Value.(map[string]interface{})["parameters"].([]interface{})
+	assert.Equal(t, "userId", patchParams[0].(map[string]interface{})["name"], "path parameters follow the existing path item")
+	deleteParams := proposal.Operations[2].Operation["parameters"].([]interface{})
+	assert.Equal(t, "id", deleteParams[0].(map[string]interface{})["name"], "the draft keeps the names of its own path")
+}
+
+func TestProposalHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder, err := NewRecorder(RecorderOptions{})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/debug/swagger/proposal", recorder.ProposalHandler(router, `{"swagger": "2.0", "info": {"title": "API", "version": "1"}, "paths": {}}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/swagger/proposal?format=patch", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var patch []PatchOperation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patch))
+	require.Len(t, patch, 2, "the debug route itself is skipped")
+	assert.Equal(t, "/paths/~1health/get", patch[1].Path)
+}
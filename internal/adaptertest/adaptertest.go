@@ -0,0 +1,269 @@
+// Package adaptertest is the conformance suite run by every adapter package, so that
+// Swagger UI, the spec, profiles, mock mode and live reload behave the same on each framework.
+//
+//	func TestConformance(t *testing.T) {
+//	    adaptertest.Run(t, func(t *testing.T, spec interface{}, config *swagger.Config) (string, error) {
+//	        router := chi.NewRouter()
+//	        router.Get("/api/health", adaptertest.Health)
+//	        if err := swaggerchi.Register(router, spec, config); err != nil {
+//	            return "", err
+//	        }
+//	        server := httptest.NewServer(router)
+//	        t.Cleanup(server.Close)
+//	        return server.URL, nil
+//	    })
+//	}
+package adaptertest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Spec documents the health route served by the app and a users route only mock mode answers
+const Spec = `{
+  "swagger": "2.0",
+  "info": {"title": "Adapter API", "version": "1.0"},
+  "paths": {
+    "/api/health": {"get": {"responses": {"200": {"description": "OK"}}}},
+    "/api/users": {"get": {"responses": {"200": {"description": "OK", "schema": {
+      "type": "array",
+      "items": {"type": "object", "properties": {"name": {"type": "string", "example": "Ada"}}}
+    }}}}}
+  }
+}`
+
+// Health is the app route every Serve function registers at GET /api/health
+func Health(w http.ResponseWriter, _ *http.Request) {
+	_, _ = io.WriteString(w, "ok")
+}
+
+// Serve starts an app serving Health at /api/health, registers spec and config on it through
+// the adapter under test and returns the URL of the running server, or the error of the adapter
+type Serve func(t *testing.T, spec interface{}, config *swagger.Config) (string, error)
+
+// response is a finished request
+type response struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// client does not follow redirects so they can be checked
+var client = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	Timeout:       5 * time.Second,
+}
+
+func get(t *testing.T, url string, prepare func(r *http.Request)) response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return response{status: resp.StatusCode, header: resp.Header, body: string(body)}
+}
+
+func quietConfig() *swagger.Config {
+	return swagger.NewConfig().WithLogger(log.New(io.Discard, "", 0))
+}
+
+func start(t *testing.T, serve Serve, spec interface{}, config *swagger.Config) string {
+	t.Helper()
+	if spec == nil {
+		var err error
+		spec, err = swagger.LoadSwagDocs(Spec)
+		require.NoError(t, err)
+	}
+	url, err := serve(t, spec, config)
+	require.NoError(t, err)
+	return url
+}
+
+// unsetSwaggerEnv removes the SWAGGER_* variables of the calling shell for the rest of the test,
+// so that SWAGGER_ENV=production does not turn off the docs under test
+func unsetSwaggerEnv(t *testing.T) {
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "SWAGGER_") {
+			t.Setenv(name, "")
+			os.Unsetenv(name)
+		}
+	}
+}
+
+// Run checks an adapter against the behaviour of the core swagger.Handler
+func Run(t *testing.T, serve Serve) {
+	unsetSwaggerEnv(t)
+	t.Run("spec", func(t *testing.T) {
+		url := start(t, serve, nil, quietConfig())
+		resp := get(t, url+"/swagger.json", func(r *http.Request) {
+			r.Header.Set("X-Forwarded-Host", "api.example.com")
+			r.Header.Set("X-Forwarded-Proto", "https")
+		})
+		require.Equal(t, http.StatusOK, resp.status)
+		assert.Contains(t, resp.header.Get("Content-Type"), "application/json")
+
+		var spec map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(resp.body), &spec))
+		assert.Equal(t, "api.example.com", spec["host"])
+		assert.Equal(t, []interface{}{"https"}, spec["schemes"])
+		assert.Contains(t, spec["paths"], "/api/users")
+
+		head, err := client.Head(url + "/swagger.json")
+		require.NoError(t, err)
+		head.Body.Close()
+		assert.Equal(t, http.StatusOK, head.StatusCode, "HEAD is served like GET")
+	})
+
+	t.Run("ui", func(t *testing.T) {
+		url := start(t, serve, nil, quietConfig().WithUIPath("/docs"))
+		resp := get(t, url+"/docs/index.html", nil)
+		require.Equal(t, http.StatusOK, resp.status)
+		assert.Contains(t, resp.header.Get("Content-Type"), "text/html")
+		assert.Contains(t, resp.body, `url: "/swagger.json"`)
+		assert.NotContains(t, resp.body, "EventSource")
+
+		resp = get(t, url+"/docs/swagger-ui.css", nil)
+		assert.Equal(t, http.StatusOK, resp.status)
+		assert.Contains(t, resp.header.Get("Content-Type"), "text/css")
+
+		resp = get(t, url+"/docs/", nil)
+		assert.Equal(t, http.StatusMovedPermanently, resp.status)
+		assert.Equal(t, "/docs/index.html", resp.header.Get("Location"))
+
+		assert.Equal(t, http.StatusNotFound, get(t, url+"/docs/missing.js", nil).status)
+		assert.Equal(t, "ok", get(t, url+"/api/health", nil).body)
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		url := start(t, serve, nil, quietConfig().WithPostmanPath("/postman.json").WithInsomniaPath("/insomnia.json"))
+		resp := get(t, url+"/postman.json", nil)
+		assert.Equal(t, http.StatusOK, resp.status)
+		assert.Contains(t, resp.body, `"name":"Adapter API"`)
+		assert.Equal(t, http.StatusOK, get(t, url+"/insomnia.json", nil).status)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		url := start(t, serve, nil, quietConfig().WithEnabled(false))
+		assert.Equal(t, http.StatusNotFound, get(t, url+"/swagger.json", nil).status)
+		assert.Equal(t, http.StatusNotFound, get(t, url+"/swagger/index.html", nil).status)
+		assert.Equal(t, "ok", get(t, url+"/api/health", nil).body)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := serve(t, Spec, quietConfig().WithUIPath("docs"))
+		assert.ErrorContains(t, err, "invalid swagger config")
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		config := quietConfig().WithEnvironment("qa").WithProfile(swagger.Profile{
+			Name:     "qa",
+			Enabled:  swagger.Bool(true),
+			TryItOut: true,
+			Auth:     map[string]string{"admin": "s3cret"},
+		})
+		url := start(t, serve, nil, config)
+
+		for _, path := range []string{"/swagger.json", "/swagger/index.html"} {
+			resp := get(t, url+path, nil)
+			assert.Equal(t, http.StatusUnauthorized, resp.status, path)
+			assert.Contains(t, resp.header.Get("WWW-Authenticate"), "Basic", path)
+
+			resp = get(t, url+path, func(r *http.Request) { r.SetBasicAuth("admin", "wrong") })
+			assert.Equal(t, http.StatusUnauthorized, resp.status, path)
+
+			resp = get(t, url+path, func(r *http.Request) { r.SetBasicAuth("admin", "s3cret") })
+			assert.Equal(t, http.StatusOK, resp.status, path)
+		}
+		assert.Equal(t, "ok", get(t, url+"/api/health", nil).body, "app routes are not protected")
+	})
+
+	t.Run("mock mode", func(t *testing.T) {
+		config := quietConfig().WithEnvironment("demo").WithProfile(swagger.Profile{
+			Name:     "demo",
+			Enabled:  swagger.Bool(true),
+			TryItOut: true,
+			Mock:     true,
+		})
+		url := start(t, serve, nil, config)
+
+		assert.Equal(t, "ok", get(t, url+"/api/health", nil).body, "app routes win over mocks")
+		resp := get(t, url+"/api/users", nil)
+		assert.Equal(t, http.StatusOK, resp.status)
+		assert.Contains(t, resp.body, `"Ada"`)
+		assert.Equal(t, http.StatusNotFound, get(t, url+"/api/orders", nil).status)
+	})
+
+	t.Run("live reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "swagger.json")
+		writeSpec(t, path, Spec, time.Hour)
+		watcher, err := swagger.NewSpecWatcher(path)
+		require.NoError(t, err)
+		watcher.WithLogger(log.New(io.Discard, "", 0))
+
+		url := start(t, serve, watcher, quietConfig())
+		assert.Contains(t, get(t, url+"/swagger/index.html", nil).body, `new EventSource("/swagger/events")`)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/swagger/events", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		// the stream has subscribed once the headers are sent
+		writeSpec(t, path, strings.Replace(Spec, "/api/users", "/api/orders", 1), time.Minute)
+		_, err = watcher.Reload()
+		require.NoError(t, err)
+
+		event, data := readEvent(bufio.NewReader(resp.Body))
+		assert.Equal(t, "event:reload", event)
+		assert.Equal(t, "data:2", data)
+		assert.Contains(t, get(t, url+"/swagger.json", nil).body, `"/api/orders"`)
+	})
+}
+
+// readEvent returns the lines of the next server-sent event, skipping comments and blank lines
+func readEvent(reader *bufio.Reader) (event, data string) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", ""
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || strings.HasPrefix(line, ":") {
+			continue
+		}
+		data, _ = reader.ReadString('\n')
+		return line, strings.TrimSuffix(data, "\n")
+	}
+}
+
+// writeSpec writes content with a distinct modification time so the watcher notices it
+func writeSpec(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,549 @@
+package swagger
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError is a single problem found in a document, located by a JSON pointer
+type ValidationError struct {
+	// Pointer is the JSON pointer (RFC 6901) of the offending value, "" for the document root
+	Pointer string `json:"pointer"`
+
+	// Message describes what is wrong
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	if e.Pointer == "" {
+		return e.Message
+	}
+	return e.Pointer + ": " + e.Message
+}
+
+// ValidationErrors is a list of validation problems returned as a single error
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+	// patternCache holds compiled "pattern" and "patternProperties" expressions
+	patternCache sync.Map
+)
+
+// schemaValidator checks JSON values against JSON Schema draft-04 as used by Swagger 2.0 and OpenAPI 3.0
+type schemaValidator struct {
+	// documents resolves refs with an absolute URL part by the "id" of the target document
+	documents map[string]interface{}
+}
+
+// validate checks value against schema and appends problems to errs.
+// doc is the document schema belongs to and is used to resolve "#/..." refs.
+func (v *schemaValidator) validate(doc, schema, value interface{}, pointer string, errs *[]ValidationError) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		// "true"-like or missing schemas accept anything
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, targetDoc, err := v.resolve(doc, ref)
+		if err != nil {
+			*errs = append(*errs, ValidationError{Pointer: pointer, Message: err.Error()})
+			return
+		}
+		v.validate(targetDoc, target, value, pointer, errs)
+		return
+	}
+
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if value == nil && (s["x-nullable"] == true || s["nullable"] == true) {
+		return
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		fail("expected %s, got %s", typeNames(t), jsonType(value))
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		fail("value must be one of %s", formatEnum(enum))
+	}
+
+	switch val := value.(type) {
+	case string:
+		v.validateString(s, val, fail)
+	case map[string]interface{}:
+		v.validateObject(doc, s, val, pointer, errs, fail)
+	case []interface{}:
+		v.validateArray(doc, s, val, pointer, errs, fail)
+	default:
+		if n, ok := toFloat(value); ok {
+			validateNumber(s, n, fail)
+		}
+	}
+
+	if allOf, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			v.validate(doc, sub, value, pointer, errs)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		if v.countMatches(doc, anyOf, value) == 0 {
+			fail("value must match at least one schema in anyOf")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		if matches := v.countMatches(doc, oneOf, value); matches != 1 {
+			fail("value must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if not, ok := s["not"]; ok && v.matches(doc, not, value) {
+		fail("value must not match the schema in not")
+	}
+}
+
+// validateString applies string keywords
+func (v *schemaValidator) validateString(s map[string]interface{}, val string, fail func(string, ...interface{})) {
+	length := utf8.RuneCountInString(val)
+	if max, ok := toFloat(s["maxLength"]); ok && float64(length) > max {
+		fail("length must be at most %v", max)
+	}
+	if min, ok := toFloat(s["minLength"]); ok && float64(length) < min {
+		fail("length must be at least %v", min)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			fail("invalid pattern %q: %v", pattern, err)
+		} else if !re.MatchString(val) {
+			fail("value must match pattern %q", pattern)
+		}
+	}
+	if format, ok := s["format"].(string); ok {
+		if msg := checkFormat(format, val); msg != "" {
+			fail("%s", msg)
+		}
+	}
+}
+
+// validateNumber applies numeric keywords
+func validateNumber(s map[string]interface{}, n float64, fail func(string, ...interface{})) {
+	if max, ok := toFloat(s["maximum"]); ok {
+		if s["exclusiveMaximum"] == true && n >= max {
+			fail("value must be less than %v", max)
+		} else if n > max {
+			fail("value must be at most %v", max)
+		}
+	}
+	if min, ok := toFloat(s["minimum"]); ok {
+		if s["exclusiveMinimum"] == true && n <= min {
+			fail("value must be greater than %v", min)
+		} else if n < min {
+			fail("value must be at least %v", min)
+		}
+	}
+	if multiple, ok := toFloat(s["multipleOf"]); ok && multiple > 0 {
+		if q := n / multiple; math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("value must be a multiple of %v", multiple)
+		}
+	}
+	switch s["format"] {
+	case "int32":
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			fail("value is out of range for int32")
+		}
+	case "int64":
+		if n < math.MinInt64 || n > math.MaxInt64 {
+			fail("value is out of range for int64")
+		}
+	}
+}
+
+// validateObject applies object keywords
+func (v *schemaValidator) validateObject(doc interface{}, s, val map[string]interface{}, pointer string, errs *[]ValidationError, fail func(string, ...interface{})) {
+	if max, ok := toFloat(s["maxProperties"]); ok && float64(len(val)) > max {
+		fail("object must have at most %v properties", max)
+	}
+	if min, ok := toFloat(s["minProperties"]); ok && float64(len(val)) < min {
+		fail("object must have at least %v properties", min)
+	}
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, present := val[key]; !present {
+					fail("missing required property %q", key)
+				}
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	patternProperties, _ := s["patternProperties"].(map[string]interface{})
+	for _, key := range sortedKeys(val) {
+		child := pointer + "/" + escapePointerToken(key)
+		matched := false
+		if sub, ok := properties[key]; ok {
+			matched = true
+			v.validate(doc, sub, val[key], child, errs)
+		}
+		for pattern, sub := range patternProperties {
+			if re, err := compilePattern(pattern); err == nil && re.MatchString(key) {
+				matched = true
+				v.validate(doc, sub, val[key], child, errs)
+			}
+		}
+		if matched {
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, ValidationError{Pointer: child, Message: fmt.Sprintf("property %q is not allowed", key)})
+			}
+		case map[string]interface{}:
+			v.validate(doc, additional, val[key], child, errs)
+		}
+	}
+
+	if dependencies, ok := s["dependencies"].(map[string]interface{}); ok {
+		for key, dep := range dependencies {
+			if _, present := val[key]; !present {
+				continue
+			}
+			if names, ok := dep.([]interface{}); ok {
+				for _, name := range names {
+					if _, present := val[fmt.Sprint(name)]; !present {
+						fail("property %q requires property %q", key, name)
+					}
+				}
+			} else {
+				v.validate(doc, dep, val, pointer, errs)
+			}
+		}
+	}
+}
+
+// validateArray applies array keywords
+func (v *schemaValidator) validateArray(doc interface{}, s map[string]interface{}, val []interface{}, pointer string, errs *[]ValidationError, fail func(string, ...interface{})) {
+	if max, ok := toFloat(s["maxItems"]); ok && float64(len(val)) > max {
+		fail("array must have at most %v items", max)
+	}
+	if min, ok := toFloat(s["minItems"]); ok && float64(len(val)) < min {
+		fail("array must have at least %v items", min)
+	}
+	if s["uniqueItems"] == true {
+		for i := range val {
+			for j := i + 1; j < len(val); j++ {
+				if valuesEqual(val[i], val[j]) {
+					fail("array items must be unique, items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+
+	switch items := s["items"].(type) {
+	case map[string]interface{}:
+		for i, item := range val {
+			v.validate(doc, items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+		}
+	case []interface{}:
+		for i, item := range val {
+			child := fmt.Sprintf("%s/%d", pointer, i)
+			if i < len(items) {
+				v.validate(doc, items[i], item, child, errs)
+				continue
+			}
+			switch additional := s["additionalItems"].(type) {
+			case bool:
+				if !additional {
+					*errs = append(*errs, ValidationError{Pointer: child, Message: "additional items are not allowed"})
+				}
+			case map[string]interface{}:
+				v.validate(doc, additional, item, child, errs)
+			}
+		}
+	}
+}
+
+// matches reports whether value is valid against schema
+func (v *schemaValidator) matches(doc, schema, value interface{}) bool {
+	var errs []ValidationError
+	v.validate(doc, schema, value, "", &errs)
+	return len(errs) == 0
+}
+
+// countMatches returns how many of schemas value is valid against
+func (v *schemaValidator) countMatches(doc interface{}, schemas []interface{}, value interface{}) int {
+	count := 0
+	for _, sub := range schemas {
+		if v.matches(doc, sub, value) {
+			count++
+		}
+	}
+	return count
+}
+
+// resolve finds the schema a ref points to and the document it lives in
+func (v *schemaValidator) resolve(doc interface{}, ref string) (interface{}, interface{}, error) {
+	file, pointer := splitRef(ref)
+	if file != "" {
+		target, ok := v.documents[file]
+		if !ok {
+			return nil, nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		doc = target
+	}
+	target, err := resolvePointer(doc, pointer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unresolvable $ref %q: %v", ref, err)
+	}
+	return target, doc, nil
+}
+
+// validateValue checks value against a schema found in doc and returns all problems
+func validateValue(doc, schema, value interface{}, pointer string) []ValidationError {
+	var errs []ValidationError
+	(&schemaValidator{}).validate(doc, schema, value, pointer, &errs)
+	return errs
+}
+
+// matchesType reports whether value has one of the JSON types named by t
+func matchesType(t, value interface{}) bool {
+	switch names := t.(type) {
+	case string:
+		return matchesTypeName(names, value)
+	case []interface{}:
+		for _, name := range names {
+			if s, ok := name.(string); ok && matchesTypeName(s, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// matchesTypeName reports whether value has the JSON type name
+func matchesTypeName(name string, value interface{}) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		n, ok := toFloat(value)
+		return ok && n == math.Trunc(n)
+	case "file":
+		// Swagger 2.0 file responses carry arbitrary content
+		return true
+	default:
+		return true
+	}
+}
+
+// jsonType names the JSON type of value for error messages
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		if n, ok := toFloat(v); ok {
+			if n == math.Trunc(n) {
+				return "integer"
+			}
+			return "number"
+		}
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// typeNames renders a "type" keyword for error messages
+func typeNames(t interface{}) string {
+	if names, ok := t.([]interface{}); ok {
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = fmt.Sprint(name)
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// toFloat converts any JSON or Go number to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// valuesEqual compares two JSON values, treating numbers of different Go types as equal
+func valuesEqual(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// containsValue reports whether enum contains value
+func containsValue(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if valuesEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEnum renders enum values for error messages
+func formatEnum(enum []interface{}) string {
+	data, err := json.Marshal(enum)
+	if err != nil {
+		return fmt.Sprint(enum)
+	}
+	return string(data)
+}
+
+// compilePattern compiles and caches a schema regular expression
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+// checkFormat validates well-known string formats and returns a message on mismatch.
+// Unknown formats are accepted.
+func checkFormat(format, value string) string {
+	switch format {
+	case "email":
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return "value must be a valid email address"
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(value) {
+			return "value must be a valid UUID"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "value must be an RFC 3339 date-time"
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "value must be a full-date (YYYY-MM-DD)"
+		}
+	case "uri":
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return "value must be an absolute URI"
+		}
+	case "uri-reference":
+		if _, err := url.Parse(value); err != nil {
+			return "value must be a URI reference"
+		}
+	case "ipv4":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return "value must be an IPv4 address"
+		}
+	case "ipv6":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return "value must be an IPv6 address"
+		}
+	case "hostname":
+		if len(value) > 253 || !hostnamePattern.MatchString(value) {
+			return "value must be a valid hostname"
+		}
+	case "byte":
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return "value must be base64 encoded"
+		}
+	case "regex":
+		if _, err := regexp.Compile(value); err != nil {
+			return "value must be a valid regular expression"
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of m in order so errors are reported deterministically
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,346 @@
+package swagger
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the importance of a lint issue
+type Severity string
+
+const (
+	// SeverityError marks issues that should fail CI
+	SeverityError Severity = "error"
+	// SeverityWarning marks issues worth fixing
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks suggestions
+	SeverityInfo Severity = "info"
+	// SeverityOff disables a rule
+	SeverityOff Severity = "off"
+)
+
+// LintIssue is a style problem reported by a lint rule
+type LintIssue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Pointer  string   `json:"pointer"`
+	Message  string   `json:"message"`
+}
+
+// String formats the issue as "severity pointer: message (rule)"
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", i.Severity, i.Pointer, i.Message, i.Rule)
+}
+
+// LintIssues is the result of linting a spec
+type LintIssues []LintIssue
+
+// HasErrors reports whether any issue has error severity
+func (issues LintIssues) HasErrors() bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintRule checks a spec for one style convention.
+// Check returns issues with Pointer and Message set; the Linter fills in Rule and Severity.
+type LintRule interface {
+	// Name is the identifier used in lint configuration (e.g., "operation-summary")
+	Name() string
+
+	// Description explains what the rule enforces
+	Description() string
+
+	// DefaultSeverity is used when the configuration does not mention the rule
+	DefaultSeverity() Severity
+
+	// Check inspects the spec and returns the issues found
+	Check(spec map[string]interface{}) []LintIssue
+}
+
+// LintConfig selects rule severities, usually loaded from a YAML file:
+//
+//	rules:
+//	  operation-summary: error
+//	  path-kebab-case: off
+type LintConfig struct {
+	Rules map[string]Severity `yaml:"rules" json:"rules"`
+}
+
+// LoadLintConfig reads a lint configuration from a YAML file
+func LoadLintConfig(path string) (*LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+	config, err := ParseLintConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseLintConfig parses a YAML lint configuration
+func ParseLintConfig(data []byte) (*LintConfig, error) {
+	var config LintConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid lint config: %w", err)
+	}
+	for name, severity := range config.Rules {
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			return nil, fmt.Errorf("rule %q: invalid severity %q (want error, warning, info or off)", name, severity)
+		}
+	}
+	return &config, nil
+}
+
+// Linter runs a set of lint rules over specs
+type Linter struct {
+	rules  []LintRule
+	config *LintConfig
+}
+
+// NewLinter creates a Linter with the built-in rules. config may be nil to use default severities.
+func NewLinter(config *LintConfig) *Linter {
+	if config == nil {
+		config = &LintConfig{}
+	}
+	return &Linter{rules: BuiltinLintRules(), config: config}
+}
+
+// WithRule adds a custom rule
+func (l *Linter) WithRule(rule LintRule) *Linter {
+	l.rules = append(l.rules, rule)
+	return l
+}
+
+// Lint checks a spec (*SwaggerSpec, swag spec from LoadSwagDocs or raw JSON) and returns issues sorted by pointer
+func (l *Linter) Lint(spec interface{}) (LintIssues, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(l.rules))
+	for _, rule := range l.rules {
+		known[rule.Name()] = true
+	}
+	for name := range l.config.Rules {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+	}
+
+	var issues LintIssues
+	for _, rule := range l.rules {
+		severity, ok := l.config.Rules[rule.Name()]
+		if !ok {
+			severity = rule.DefaultSeverity()
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		for _, issue := range rule.Check(specMap) {
+			issue.Rule = rule.Name()
+			issue.Severity = severity
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Pointer < issues[j].Pointer })
+	return issues, nil
+}
+
+// Lint checks a spec with the built-in rules and the given configuration (nil for defaults).
+//
+// Example:
+//
+//	config, _ := swagger.LoadLintConfig(".swagger-lint.yaml")
+//	issues, err := swagger.Lint(swagSpec, config)
+//	for _, issue := range issues {
+//	    fmt.Println(issue)
+//	}
+//	if issues.HasErrors() {
+//	    os.Exit(1)
+//	}
+func Lint(spec interface{}, config *LintConfig) (LintIssues, error) {
+	return NewLinter(config).Lint(spec)
+}
+
+// BuiltinLintRules returns the rules shipped with the package
+func BuiltinLintRules() []LintRule {
+	return []LintRule{
+		operationRule{
+			name:        "operation-summary",
+			description: "every operation has a summary",
+			severity:    SeverityWarning,
+			check: func(op specOperation) string {
+				if summary, _ := op.Operation["summary"].(string); strings.TrimSpace(summary) == "" {
+					return "operation has no summary"
+				}
+				return ""
+			},
+		},
+		operationRule{
+			name:        "operation-tags",
+			description: "every operation has at least one tag",
+			severity:    SeverityWarning,
+			check: func(op specOperation) string {
+				if len(stringSlice(op.Operation["tags"])) == 0 {
+					return "operation has no tags"
+				}
+				return ""
+			},
+		},
+		operationRule{
+			name:        "operation-id-camel-case",
+			description: "operationIds are camelCase",
+			severity:    SeverityWarning,
+			check: func(op specOperation) string {
+				id, _ := op.Operation["operationId"].(string)
+				if id != "" && !camelCasePattern.MatchString(id) {
+					return fmt.Sprintf("operationId %q is not camelCase", id)
+				}
+				return ""
+			},
+		},
+		pathKebabCaseRule{},
+		operationRule{
+			name:        "response-4xx",
+			description: "every operation documents at least one 4xx response",
+			severity:    SeverityWarning,
+			check: func(op specOperation) string {
+				responses, _ := op.Operation["responses"].(map[string]interface{})
+				for code := range responses {
+					if strings.HasPrefix(code, "4") {
+						return ""
+					}
+				}
+				return "operation documents no 4xx response"
+			},
+		},
+		noInlineResponseSchemaRule{},
+		operationRule{
+			name:        "operation-security",
+			description: "operations declare security unless marked public with `security: []` or `x-public: true`",
+			severity:    SeverityError,
+			checkSpec: func(spec map[string]interface{}, op specOperation) string {
+				if op.Operation["x-public"] == true {
+					return ""
+				}
+				// An operation-level list, even an empty one marking it public, overrides the global one
+				if _, ok := op.Operation["security"].([]interface{}); ok {
+					return ""
+				}
+				if global, _ := spec["security"].([]interface{}); len(global) > 0 {
+					return ""
+				}
+				return "operation declares no security and is not marked public"
+			},
+		},
+	}
+}
+
+var (
+	camelCasePattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	kebabCasePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// operationRule is a rule that checks each operation independently
+type operationRule struct {
+	name        string
+	description string
+	severity    Severity
+	check       func(op specOperation) string
+	checkSpec   func(spec map[string]interface{}, op specOperation) string
+}
+
+func (r operationRule) Name() string              { return r.name }
+func (r operationRule) Description() string       { return r.description }
+func (r operationRule) DefaultSeverity() Severity { return r.severity }
+
+func (r operationRule) Check(spec map[string]interface{}) []LintIssue {
+	var issues []LintIssue
+	for _, op := range specOperations(spec) {
+		var message string
+		if r.checkSpec != nil {
+			message = r.checkSpec(spec, op)
+		} else {
+			message = r.check(op)
+		}
+		if message != "" {
+			issues = append(issues, LintIssue{Pointer: op.Pointer, Message: message})
+		}
+	}
+	return issues
+}
+
+// pathKebabCaseRule requires static path segments to be kebab-case
+type pathKebabCaseRule struct{}
+
+func (pathKebabCaseRule) Name() string              { return "path-kebab-case" }
+func (pathKebabCaseRule) Description() string       { return "static path segments are kebab-case" }
+func (pathKebabCaseRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (pathKebabCaseRule) Check(spec map[string]interface{}) []LintIssue {
+	var issues []LintIssue
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+			if segment == "" || pathParamPattern.MatchString(segment) {
+				continue
+			}
+			if !kebabCasePattern.MatchString(segment) {
+				issues = append(issues, LintIssue{
+					Pointer: "/paths/" + escapePointerToken(path),
+					Message: fmt.Sprintf("path segment %q is not kebab-case", segment),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// noInlineResponseSchemaRule requires object response schemas to reference a definition
+type noInlineResponseSchemaRule struct{}
+
+func (noInlineResponseSchemaRule) Name() string { return "no-inline-response-schema" }
+func (noInlineResponseSchemaRule) Description() string {
+	return "response object schemas reference a definition instead of being inlined"
+}
+func (noInlineResponseSchemaRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (noInlineResponseSchemaRule) Check(spec map[string]interface{}) []LintIssue {
+	var issues []LintIssue
+	for _, op := range specOperations(spec) {
+		responses, _ := op.Operation["responses"].(map[string]interface{})
+		for _, code := range sortedKeys(responses) {
+			response, _ := responses[code].(map[string]interface{})
+			schema, _ := response["schema"].(map[string]interface{})
+			// Arrays are fine as long as their items reference a definition
+			if items, ok := schema["items"].(map[string]interface{}); ok && schema["type"] == "array" {
+				schema = items
+			}
+			if _, isRef := schema["$ref"]; isRef {
+				continue
+			}
+			if _, hasProps := schema["properties"]; hasProps {
+				issues = append(issues, LintIssue{
+					Pointer: op.Pointer + "/responses/" + escapePointerToken(code) + "/schema",
+					Message: fmt.Sprintf("response %s has an inline object schema", code),
+				})
+			}
+		}
+	}
+	return issues
+}
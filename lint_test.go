@@ -0,0 +1,96 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"paths": {
+		"/user_profiles/{id}": {
+			"get": {
+				"operationId": "GetProfile",
+				"responses": {"200": {"description": "OK", "schema": {"type": "object", "properties": {"id": {"type": "integer"}}}}}
+			}
+		},
+		"/health": {
+			"get": {
+				"operationId": "health",
+				"summary": "Health check",
+				"tags": ["system"],
+				"security": [],
+				"responses": {"200": {"description": "OK"}, "503": {"description": "Unavailable"}}
+			}
+		},
+		"/users": {
+			"get": {
+				"operationId": "listUsers",
+				"summary": "List users",
+				"tags": ["users"],
+				"security": [{"Bearer": []}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/User"}}}, "401": {"description": "Unauthorized"}}
+			}
+		}
+	}
+}`
+
+func lintRules(issues LintIssues, pointer string) []string {
+	var rules []string
+	for _, issue := range issues {
+		if issue.Pointer == pointer {
+			rules = append(rules, issue.Rule)
+		}
+	}
+	return rules
+}
+
+func TestLint(t *testing.T) {
+	t.Run("built-in rules", func(t *testing.T) {
+		issues, err := Lint(lintSpec, nil)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"operation-summary",
+			"operation-tags",
+			"operation-id-camel-case",
+			"response-4xx",
+			"operation-security",
+		}, lintRules(issues, "/paths/~1user_profiles~1{id}/get"))
+		assert.Equal(t, []string{"path-kebab-case"}, lintRules(issues, "/paths/~1user_profiles~1{id}"))
+		assert.Equal(t, []string{"no-inline-response-schema"}, lintRules(issues, "/paths/~1user_profiles~1{id}/get/responses/200/schema"))
+		assert.Empty(t, lintRules(issues, "/paths/~1users/get"))
+		assert.Equal(t, []string{"response-4xx"}, lintRules(issues, "/paths/~1health/get"))
+		assert.True(t, issues.HasErrors())
+	})
+
+	t.Run("config changes severities", func(t *testing.T) {
+		config, err := ParseLintConfig([]byte(`
+rules:
+  operation-security: off
+  operation-summary: error
+`))
+		require.NoError(t, err)
+
+		issues, err := Lint(lintSpec, config)
+		require.NoError(t, err)
+
+		for _, issue := range issues {
+			assert.NotEqual(t, "operation-security", issue.Rule)
+			if issue.Rule == "operation-summary" {
+				assert.Equal(t, SeverityError, issue.Severity)
+			}
+		}
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		_, err := ParseLintConfig([]byte("rules:\n  operation-summary: fatal\n"))
+		assert.ErrorContains(t, err, `rule "operation-summary": invalid severity "fatal"`)
+
+		_, err = Lint(lintSpec, &LintConfig{Rules: map[string]Severity{"no-such-rule": SeverityError}})
+		assert.ErrorContains(t, err, `unknown lint rule "no-such-rule"`)
+	})
+}
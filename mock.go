@@ -0,0 +1,233 @@
+package swagger
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MockOptions configures the mock server
+type MockOptions struct {
+	// Latency delays every response
+	Latency time.Duration
+
+	// Jitter adds a random delay of up to this duration on top of Latency
+	Jitter time.Duration
+
+	// ErrorRate is the fraction of requests answered with ErrorStatus instead of a mock response, between 0 and 1
+	ErrorRate float64
+
+	// ErrorStatus is the status of injected errors (default: 500)
+	ErrorStatus int
+
+	// Seed makes synthesised bodies reproducible: every request to an operation gets the same data
+	Seed int64
+}
+
+// SetupMock registers a gin route for every operation of a Swagger 2.0 spec answering with mock data.
+//
+// Responses use the documented examples (the values swag collects from `example:"..."` tags)
+// or data synthesised from the response schema. Clients pick alternate responses with the
+// Prefer header:
+//
+//	Prefer: code=404             answer with the documented 404 response
+//	Prefer: example=admin        answer with the named example from the response's x-examples
+//
+// Routes are registered under the spec's basePath. Routes conflicting with each other or, when
+// router is a *gin.Engine, with its routes are returned as an error before any is registered.
+//
+// Example:
+//
+//	router := gin.Default()
+//	if err := swagger.SetupMock(router, swagSpec, swagger.MockOptions{Latency: 200 * time.Millisecond}); err != nil {
+//	    log.Fatal(err)
+//	}
+//	router.Run(":8080")
+func SetupMock(router gin.IRouter, spec interface{}, options MockOptions) (err error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return err
+	}
+	if isOpenAPI3(specMap) {
+		return fmt.Errorf("mock server supports Swagger 2.0 specs only")
+	}
+	if options.ErrorRate < 0 || options.ErrorRate > 1 {
+		return fmt.Errorf("error rate must be between 0 and 1, got %v", options.ErrorRate)
+	}
+	if options.ErrorStatus == 0 {
+		options.ErrorStatus = http.StatusInternalServerError
+	}
+
+	basePath, _ := specMap["basePath"].(string)
+	group := router.Group(strings.TrimSuffix(basePath, "/"))
+
+	// gin panics on conflicting routes, e.g. differently named params at the same position, so
+	// they are checked first against the routes of an engine, or each other for a group
+	operations := specOperations(specMap)
+	var routes []gin.RouteInfo
+	for _, op := range operations {
+		path := strings.TrimSuffix(group.BasePath(), "/") + specPathToGin(op.Path)
+		routes = append(routes, gin.RouteInfo{Method: strings.ToUpper(op.Method), Path: path})
+	}
+	existing, ok := router.(*gin.Engine)
+	if !ok {
+		existing = gin.New()
+	}
+	if err := checkRoutes(existing, routes); err != nil {
+		return fmt.Errorf("failed to register mock routes: %w", err)
+	}
+
+	// a group's engine may still hold a conflicting route
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to register mock routes: %v", r)
+		}
+	}()
+	for _, op := range operations {
+		group.Handle(strings.ToUpper(op.Method), specPathToGin(op.Path), mockHandler(specMap, op, options))
+	}
+	return nil
+}
+
+// mockHandler answers requests for one operation
+func mockHandler(spec map[string]interface{}, op specOperation, options MockOptions) gin.HandlerFunc {
+	responses, _ := op.Operation["responses"].(map[string]interface{})
+
+	return func(c *gin.Context) {
+		delay := options.Latency
+		if options.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(options.Jitter)))
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		if options.ErrorRate > 0 && rand.Float64() < options.ErrorRate {
+			writeProblem(c, ProblemDetails{
+				Type:     "about:blank",
+				Title:    "Injected error",
+				Status:   options.ErrorStatus,
+				Instance: c.Request.URL.Path,
+			})
+			return
+		}
+
+		prefer := parsePrefer(c.Request.Header.Values("Prefer"))
+		code := prefer["code"]
+		if code == "" {
+			code = defaultResponseCode(responses)
+		}
+		response, ok := responses[code].(map[string]interface{})
+		if !ok {
+			writeProblem(c, ProblemDetails{
+				Type:     "about:blank",
+				Title:    "No mock response",
+				Status:   http.StatusBadRequest,
+				Detail:   fmt.Sprintf("%s %s documents no %s response", strings.ToUpper(op.Method), op.Path, code),
+				Instance: c.Request.URL.Path,
+			})
+			return
+		}
+		if ref, _ := response["$ref"].(string); ref != "" {
+			if resolved, err := ResolveRef(spec, ref); err == nil {
+				response, _ = resolved.(map[string]interface{})
+			}
+		}
+
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			// "default" responses
+			status = http.StatusOK
+		}
+
+		headers, _ := response["headers"].(map[string]interface{})
+		for _, name := range sortedKeys(headers) {
+			header, _ := headers[name].(map[string]interface{})
+			if value, ok := header["example"]; ok {
+				c.Header(name, fmt.Sprint(value))
+			} else if value, ok := header["default"]; ok {
+				c.Header(name, fmt.Sprint(value))
+			}
+		}
+
+		generator, _ := NewGenerator(spec, options.Seed)
+		body, found := mockBody(generator, response, prefer["example"])
+		if !found {
+			writeProblem(c, ProblemDetails{
+				Type:     "about:blank",
+				Title:    "No mock response",
+				Status:   http.StatusBadRequest,
+				Detail:   fmt.Sprintf("response %s of %s %s has no example %q", code, strings.ToUpper(op.Method), op.Path, prefer["example"]),
+				Instance: c.Request.URL.Path,
+			})
+			return
+		}
+		if body == nil {
+			c.Status(status)
+			return
+		}
+		c.JSON(status, body)
+	}
+}
+
+// mockBody picks the body of a mock response. found is false when a requested named example does not exist.
+func mockBody(generator *Generator, response map[string]interface{}, exampleName string) (body interface{}, found bool) {
+	if exampleName != "" {
+		named, _ := response["x-examples"].(map[string]interface{})
+		body, found = named[exampleName]
+		return body, found
+	}
+	if examples, ok := response["examples"].(map[string]interface{}); ok {
+		if example, ok := examples["application/json"]; ok {
+			return example, true
+		}
+	}
+	schema, ok := response["schema"]
+	if !ok {
+		return nil, true
+	}
+	return generator.Generate(schema), true
+}
+
+// defaultResponseCode picks the lowest documented 2xx code, then "default", then the lowest code
+func defaultResponseCode(responses map[string]interface{}) string {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if code != "default" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			return code
+		}
+	}
+	if _, ok := responses["default"]; ok || len(codes) == 0 {
+		return "default"
+	}
+	return codes[0]
+}
+
+// parsePrefer reads key=value preferences from Prefer headers (RFC 7240)
+func parsePrefer(values []string) map[string]string {
+	prefer := make(map[string]string)
+	for _, value := range values {
+		for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+			key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+			prefer[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+	return prefer
+}
+
+// specPathToGin converts a spec path template ("/users/{id}") into a gin route ("/users/:id")
+func specPathToGin(path string) string {
+	return pathParamPattern.ReplaceAllString(path, ":$1")
+}
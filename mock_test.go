@@ -0,0 +1,178 @@
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mockSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"responses": {
+					"200": {
+						"description": "OK",
+						"schema": {"$ref": "#/definitions/User"},
+						"headers": {"X-Rate-Limit": {"type": "integer", "example": 100}},
+						"x-examples": {"admin": {"id": 1, "name": "Root", "role": "admin"}}
+					},
+					"404": {"description": "Not found", "schema": {"$ref": "#/definitions/Error"}}
+				}
+			},
+			"delete": {
+				"responses": {"204": {"description": "Deleted"}}
+			}
+		},
+		"/status": {
+			"get": {
+				"responses": {
+					"200": {"description": "OK", "examples": {"application/json": {"status": "up"}}}
+				}
+			}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "example": 42},
+				"name": {"type": "string", "example": "Jane"},
+				"email": {"type": "string", "format": "email"},
+				"role": {"type": "string", "enum": ["member", "admin"]},
+				"manager": {"$ref": "#/definitions/User"}
+			}
+		},
+		"Error": {
+			"type": "object",
+			"properties": {"message": {"type": "string", "example": "not found"}}
+		}
+	}
+}`
+
+func newMockRouter(t *testing.T, options MockOptions) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	require.NoError(t, SetupMock(router, mockSpec, options))
+	return router
+}
+
+func mockRequest(router *gin.Engine, method, path, prefer string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if prefer != "" {
+		req.Header.Set("Prefer", prefer)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSetupMock(t *testing.T) {
+	router := newMockRouter(t, MockOptions{})
+
+	t.Run("synthesises the body from schema examples", func(t *testing.T) {
+		w := mockRequest(router, http.MethodGet, "/api/users/7", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "100", w.Header().Get("X-Rate-Limit"))
+		var user map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, float64(42), user["id"])
+		assert.Equal(t, "Jane", user["name"])
+		assert.Contains(t, []interface{}{"member", "admin"}, user["role"])
+		assert.Contains(t, user["email"], "@example.com")
+
+		again := mockRequest(router, http.MethodGet, "/api/users/7", "")
+		assert.Equal(t, w.Body.String(), again.Body.String(), "bodies are reproducible")
+	})
+
+	t.Run("uses documented response examples", func(t *testing.T) {
+		w := mockRequest(router, http.MethodGet, "/api/status", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status": "up"}`, w.Body.String())
+	})
+
+	t.Run("Prefer code selects another response", func(t *testing.T) {
+		w := mockRequest(router, http.MethodGet, "/api/users/7", "code=404")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"message": "not found"}`, w.Body.String())
+	})
+
+	t.Run("Prefer example selects a named example", func(t *testing.T) {
+		w := mockRequest(router, http.MethodGet, "/api/users/7", `example="admin"`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 1, "name": "Root", "role": "admin"}`, w.Body.String())
+	})
+
+	t.Run("unknown preferences are rejected", func(t *testing.T) {
+		for _, prefer := range []string{"code=500", "example=missing"} {
+			w := mockRequest(router, http.MethodGet, "/api/users/7", prefer)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, prefer)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"), prefer)
+		}
+	})
+
+	t.Run("responses without schema have no body", func(t *testing.T) {
+		w := mockRequest(router, http.MethodDelete, "/api/users/7", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
+
+func TestSetupMockInjection(t *testing.T) {
+	t.Run("error injection", func(t *testing.T) {
+		router := newMockRouter(t, MockOptions{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable})
+		w := mockRequest(router, http.MethodGet, "/api/status", "")
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var problem ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Injected error", problem.Title)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		router := newMockRouter(t, MockOptions{Latency: 20 * time.Millisecond})
+		start := time.Now()
+		mockRequest(router, http.MethodGet, "/api/status", "")
+
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("invalid error rate", func(t *testing.T) {
+		assert.Error(t, SetupMock(gin.New(), mockSpec, MockOptions{ErrorRate: 2}))
+	})
+
+	t.Run("conflicting routes are reported", func(t *testing.T) {
+		spec := `{"swagger": "2.0", "info": {"title": "API", "version": "1.0"}, "paths": {
+			"/a/{id}": {"get": {"responses": {"200": {"description": "OK"}}}},
+			"/a/{name}/b": {"get": {"responses": {"200": {"description": "OK"}}}}
+		}}`
+		router := gin.New()
+		assert.Error(t, SetupMock(router, spec, MockOptions{}))
+		assert.Empty(t, router.Routes(), "nothing is registered")
+	})
+
+	t.Run("conflicts with existing routes are reported", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/api/users/:name", func(c *gin.Context) {})
+		err := SetupMock(router, mockSpec, MockOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to register mock routes")
+		assert.Len(t, router.Routes(), 1, "nothing is registered")
+	})
+}
@@ -0,0 +1,73 @@
+package swagger
+
+// httpMethods lists the path item keys that hold operations
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// specOperation is a single operation found in the paths of a spec
+type specOperation struct {
+	// Path is the path template as written in the spec (e.g., "/users/{id}")
+	Path string
+
+	// Method is the lower-case HTTP method
+	Method string
+
+	// Operation is the operation object
+	Operation map[string]interface{}
+
+	// PathItem is the path item holding the operation
+	PathItem map[string]interface{}
+
+	// Pointer is the JSON pointer of the operation object
+	Pointer string
+}
+
+// specOperations lists the operations of a spec sorted by path, then method
+func specOperations(spec map[string]interface{}) []specOperation {
+	var ops []specOperation
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range httpMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ops = append(ops, specOperation{
+				Path:      path,
+				Method:    method,
+				Operation: op,
+				PathItem:  item,
+				Pointer:   "/paths/" + escapePointerToken(path) + "/" + method,
+			})
+		}
+	}
+	return ops
+}
+
+// parameters returns the effective parameters of the operation with internal refs resolved
+func (o specOperation) parameters(spec map[string]interface{}) []map[string]interface{} {
+	pathParams, _ := o.PathItem["parameters"].([]interface{})
+	opParams, _ := o.Operation["parameters"].([]interface{})
+	return effectiveParameters(spec, pathParams, opParams)
+}
+
+// stringSlice converts a JSON array of strings into a []string, skipping other values
+func stringSlice(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,300 @@
+package swagger
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Profile bundles the documentation settings of one deployment stage
+type Profile struct {
+	// Name identifies the profile; it is served in the spec as x-environment
+	Name string `json:"name"`
+
+	// Enabled serves or hides the UI and the spec when the config leaves Config.Enabled at its
+	// default: set neither through WithEnabled, a config file or SWAGGER_ENABLED, nor to false.
+	// nil keeps Config.Enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Auth protects the UI, spec and collection routes with HTTP basic auth (user → password)
+	Auth map[string]string `json:"auth,omitempty"`
+
+	// TryItOut enables the "Try it out" button of Swagger UI
+	TryItOut bool `json:"tryItOut"`
+
+	// Audiences limits the served operations to those whose x-audience is listed.
+	// Operations without x-audience are always served; empty serves everything.
+	Audiences []string `json:"audiences,omitempty"`
+
+	// Mock answers requests to documented operations that have no handler with mock data.
+	// It takes over the router's not-found handler (NoRoute with gin).
+	Mock bool `json:"mock"`
+
+	// Servers are the base URLs the API is reachable at (e.g., "https://api.example.com/v1").
+	// They replace host detection: the first sets host and basePath, OpenAPI 3 specs list all.
+	Servers []string `json:"servers,omitempty"`
+
+	// DefaultScheme is used when a request tells neither through TLS nor X-Forwarded-Proto
+	// Default: "http"
+	DefaultScheme string `json:"defaultScheme,omitempty"`
+}
+
+// environmentEnv selects the active profile when Config.Environment is empty.
+// The generic ENV variable is not read: other tools set it too, and a profile can turn the docs off.
+const environmentEnv = "SWAGGER_ENV"
+
+// Bool returns a pointer to v, for Profile.Enabled
+func Bool(v bool) *bool {
+	return &v
+}
+
+// DevelopmentProfile serves everything with "Try it out" enabled
+func DevelopmentProfile() Profile {
+	return Profile{Name: "development", Enabled: Bool(true), TryItOut: true, DefaultScheme: "http"}
+}
+
+// StagingProfile serves everything with "Try it out" enabled, assuming https
+func StagingProfile() Profile {
+	return Profile{Name: "staging", Enabled: Bool(true), TryItOut: true, DefaultScheme: "https"}
+}
+
+// ProductionProfile disables the documentation. When enabled by a custom profile based on it,
+// only operations for the "public" audience are served and "Try it out" stays off.
+func ProductionProfile() Profile {
+	return Profile{Name: "production", Enabled: Bool(false), Audiences: []string{"public"}, DefaultScheme: "https"}
+}
+
+// profileAliases maps common short names to the built-in profiles
+var profileAliases = map[string]string{
+	"dev":   "development",
+	"local": "development",
+	"stage": "staging",
+	"prod":  "production",
+}
+
+// environment returns the name of the selected profile: Config.Environment, else SWAGGER_ENV
+func (c *Config) environment() string {
+	name := c.Environment
+	if name == "" {
+		name = os.Getenv(environmentEnv)
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// lookupProfile finds a profile by name or alias among Config.Profiles, then the built-in profiles
+func (c *Config) lookupProfile(name string) (Profile, bool) {
+	if _, ok := c.Profiles[name]; !ok {
+		if alias, ok := profileAliases[name]; ok {
+			name = alias
+		}
+	}
+	if profile, ok := c.Profiles[name]; ok {
+		if profile.Name == "" {
+			profile.Name = name
+		}
+		return profile, true
+	}
+	switch name {
+	case "development":
+		return DevelopmentProfile(), true
+	case "staging":
+		return StagingProfile(), true
+	case "production":
+		return ProductionProfile(), true
+	}
+	return Profile{}, false
+}
+
+// ActiveProfile returns the profile selected by Config.Environment or SWAGGER_ENV,
+// or nil when none is selected or the name is unknown (Validate reports unknown names)
+func (c *Config) ActiveProfile() *Profile {
+	name := c.environment()
+	if name == "" {
+		return nil
+	}
+	profile, ok := c.lookupProfile(name)
+	if !ok {
+		return nil
+	}
+	return &profile
+}
+
+// applyProfile makes the active profile's Enabled setting take effect, unless the caller decided:
+// Enabled was set explicitly, or is false, which is never the default
+func (c *Config) applyProfile() {
+	profile := c.ActiveProfile()
+	if profile == nil || profile.Enabled == nil || c.enabledSet || !c.Enabled {
+		return
+	}
+	c.Enabled = *profile.Enabled
+}
+
+// defaultScheme is the scheme assumed for requests that carry no scheme hint
+func (c *Config) defaultScheme() string {
+	if profile := c.ActiveProfile(); profile != nil && profile.DefaultScheme != "" {
+		return profile.DefaultScheme
+	}
+	return "http"
+}
+
+// validateProfiles checks the selected profile name and the settings of every defined profile
+func validateProfiles(config *Config) []ConfigError {
+	var errs []ConfigError
+	if name := config.environment(); name != "" {
+		if _, ok := config.lookupProfile(name); !ok {
+			errs = append(errs, ConfigError{Field: "environment", Message: fmt.Sprintf("unknown profile %q", name)})
+		}
+	}
+
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile := config.Profiles[name]
+		field := "profiles." + name
+		if profile.DefaultScheme != "" && profile.DefaultScheme != "http" && profile.DefaultScheme != "https" {
+			errs = append(errs, ConfigError{Field: field, Message: fmt.Sprintf("defaultScheme must be http or https, got %q", profile.DefaultScheme)})
+		}
+		for _, server := range profile.Servers {
+			if u, err := url.Parse(server); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, ConfigError{Field: field, Message: fmt.Sprintf("server must be an absolute http or https URL, got %q", server)})
+			}
+		}
+	}
+	return errs
+}
+
+// applyServers sets host, schemes and basePath (Swagger 2.0) or servers (OpenAPI 3) of a served spec
+func applyServers(spec map[string]interface{}, servers []string) {
+	if isOpenAPI3(spec) {
+		list := make([]interface{}, len(servers))
+		for i, server := range servers {
+			list[i] = map[string]interface{}{"url": server}
+		}
+		spec["servers"] = list
+		return
+	}
+
+	first, err := url.Parse(servers[0])
+	if err != nil {
+		return
+	}
+	var schemes []string
+	for _, server := range servers {
+		if u, err := url.Parse(server); err == nil && u.Host == first.Host {
+			schemes = appendUnique(schemes, u.Scheme)
+		}
+	}
+	spec["host"] = first.Host
+	spec["schemes"] = schemes
+	if path := strings.TrimSuffix(first.Path, "/"); path != "" {
+		spec["basePath"] = path
+	}
+}
+
+// filterAudiences drops operations whose x-audience lists none of the audiences,
+// and path items left without operations
+func filterAudiences(spec map[string]interface{}, audiences []string) {
+	paths, ok := spec["paths"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	allowed := make(map[string]bool, len(audiences))
+	for _, audience := range audiences {
+		allowed[audience] = true
+	}
+	methods := make(map[string]bool, len(httpMethods))
+	for _, method := range httpMethods {
+		methods[method] = true
+	}
+
+	filtered := make(map[string]interface{}, len(paths))
+	for path, value := range paths {
+		item, ok := value.(map[string]interface{})
+		if !ok {
+			filtered[path] = value
+			continue
+		}
+		kept := make(map[string]interface{}, len(item))
+		operations := 0
+		for key, op := range item {
+			if !methods[key] {
+				kept[key] = op
+				continue
+			}
+			opMap, _ := op.(map[string]interface{})
+			if visibleTo(opMap, allowed) {
+				kept[key] = op
+				operations++
+			}
+		}
+		if operations > 0 {
+			filtered[path] = kept
+		}
+	}
+	spec["paths"] = filtered
+}
+
+// visibleTo reports whether an operation without x-audience, or with one of the allowed audiences, is served
+func visibleTo(op map[string]interface{}, allowed map[string]bool) bool {
+	value, ok := op["x-audience"]
+	if !ok {
+		return true
+	}
+	audiences := stringSlice(value)
+	if s, ok := value.(string); ok {
+		audiences = []string{s}
+	}
+	for _, audience := range audiences {
+		if allowed[audience] {
+			return true
+		}
+	}
+	return false
+}
+
+// decorateForProfile marks a served spec map with the profile name and filters it by audience.
+// Servers are applied with host detection by applyDetectedHost.
+func decorateForProfile(spec map[string]interface{}, profile *Profile) {
+	spec["x-environment"] = profile.Name
+	if len(profile.Audiences) > 0 {
+		filterAudiences(spec, profile.Audiences)
+	}
+}
+
+// profileMockHandler answers unmatched requests to documented operations with mock data.
+// It is installed as the router's NoRoute handler; other requests get gin's default 404.
+func profileMockHandler(spec func() interface{}, version func() int64) gin.HandlerFunc {
+	var mu sync.Mutex
+	var matcher *operationMatcher
+	var matched int64
+	current := func() *operationMatcher {
+		mu.Lock()
+		defer mu.Unlock()
+		// the matcher is only rebuilt when the spec has been reloaded since it was compiled
+		if v := version(); matcher == nil || v != matched {
+			if compiled, err := newOperationMatcher(spec()); err == nil {
+				matcher, matched = compiled, v
+			}
+		}
+		return matcher
+	}
+	return func(c *gin.Context) {
+		matcher := current()
+		if matcher == nil {
+			return
+		}
+		route, _ := matcher.match(c.Request.Method, "", c.Request.URL.Path)
+		if route == nil {
+			return
+		}
+		mockHandler(matcher.spec, route.specOperation, MockOptions{})(c)
+	}
+}
@@ -0,0 +1,242 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profileSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/orders": {
+			"get": {"x-audience": "partner", "responses": {"200": {"description": "OK", "examples": {"application/json": [{"id": 1}]}}}},
+			"delete": {"x-audience": ["internal"], "responses": {"204": {"description": "Deleted"}}}
+		},
+		"/health": {"get": {"responses": {"200": {"description": "OK"}}}},
+		"/admin": {"get": {"x-audience": "internal", "responses": {"200": {"description": "OK"}}}}
+	}
+}`
+
+func quietConfig() *Config {
+	return DefaultConfig().WithLogger(log.New(&bytes.Buffer{}, "", 0))
+}
+
+func TestActiveProfile(t *testing.T) {
+	t.Run("none selected", func(t *testing.T) {
+		assert.Nil(t, NewConfig().ActiveProfile())
+	})
+
+	t.Run("ENV is not read", func(t *testing.T) {
+		t.Setenv("ENV", "production")
+		config := NewConfig()
+		assert.Nil(t, config.ActiveProfile())
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("SWAGGER_ENV alias", func(t *testing.T) {
+		t.Setenv("SWAGGER_ENV", "prod")
+		assert.Equal(t, ProductionProfile(), *NewConfig().ActiveProfile())
+	})
+
+	t.Run("explicit unknown name", func(t *testing.T) {
+		err := NewConfig().WithEnvironment("qa").Validate()
+		var problems ConfigErrors
+		require.True(t, errors.As(err, &problems))
+		assert.Equal(t, ConfigErrors{{Field: "environment", Message: `unknown profile "qa"`}}, problems)
+	})
+
+	t.Run("custom profile replaces the built-in one", func(t *testing.T) {
+		config := NewConfig().WithEnvironment("Production").WithProfile(Profile{Name: "production", Enabled: Bool(true)})
+		assert.Equal(t, &Profile{Name: "production", Enabled: Bool(true)}, config.ActiveProfile())
+	})
+
+	t.Run("invalid profile settings", func(t *testing.T) {
+		config := NewConfig().WithProfile(Profile{Name: "edge", DefaultScheme: "ftp", Servers: []string{"api.example.com"}})
+		assert.Equal(t, []ConfigError{
+			{Field: "profiles.edge", Message: `defaultScheme must be http or https, got "ftp"`},
+			{Field: "profiles.edge", Message: `server must be an absolute http or https URL, got "api.example.com"`},
+		}, validateProfiles(config))
+	})
+}
+
+func TestProfileDisablesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("SWAGGER_ENV", "production")
+
+	router := gin.New()
+	require.NoError(t, Setup(router, quietConfig()))
+	assert.Empty(t, router.Routes())
+}
+
+func TestProfileRespectsExplicitEnabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		env     string
+		config  func() *Config
+		enabled bool
+	}{
+		{"disabled in code stays disabled on staging", "staging", func() *Config { return quietConfig().WithEnabled(false) }, false},
+		{"disabled in code stays disabled on dev", "dev", func() *Config { return quietConfig().WithEnabled(false) }, false},
+		{"disabled field stays disabled", "development", func() *Config {
+			config := quietConfig()
+			config.Enabled = false
+			return config
+		}, false},
+		{"enabled in code stays enabled on production", "production", func() *Config { return quietConfig().WithEnabled(true) }, true},
+		{"default follows production", "production", quietConfig, false},
+		{"profile without enabled keeps the default", "partners", func() *Config {
+			return quietConfig().WithProfile(Profile{Name: "partners", Audiences: []string{"partner"}})
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SWAGGER_ENV", tt.env)
+			router := gin.New()
+			require.NoError(t, Setup(router, tt.config()))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+			if tt.enabled {
+				assert.Equal(t, http.StatusOK, w.Code)
+			} else {
+				assert.Equal(t, http.StatusNotFound, w.Code)
+				assert.Empty(t, router.Routes())
+			}
+		})
+	}
+}
+
+func TestProfileServedSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(profileSpec), &spec))
+
+	var logs bytes.Buffer
+	config := DefaultConfig().
+		WithLogger(log.New(&logs, "", 0)).
+		WithEnvironment("partners").
+		WithProfile(Profile{
+			Name:      "partners",
+			Enabled:   Bool(true),
+			Auth:      gin.Accounts{"partner": "s3cret"},
+			Audiences: []string{"partner"},
+			Servers:   []string{"https://partners.example.com/api", "http://partners.example.com/api"},
+			Mock:      true,
+		})
+
+	router := gin.New()
+	router.GET("/api/health", func(c *gin.Context) { c.String(http.StatusOK, "real") })
+	require.NoError(t, SetupWithSwag(router, spec, config))
+	assert.Contains(t, logs.String(), "servers https://partners.example.com/api, http://partners.example.com/api | environment partners | basic auth | mock mode")
+
+	get := func(path string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth {
+			req.SetBasicAuth("partner", "s3cret")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("basic auth", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("/swagger.json", false).Code)
+		assert.Equal(t, http.StatusUnauthorized, get("/swagger/index.html", false).Code)
+	})
+
+	t.Run("spec", func(t *testing.T) {
+		w := get("/swagger.json", true)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var served map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+		assert.Equal(t, "partners", served["x-environment"])
+		assert.Equal(t, "partners.example.com", served["host"])
+		assert.Equal(t, []interface{}{"https", "http"}, served["schemes"])
+
+		paths := served["paths"].(map[string]interface{})
+		assert.ElementsMatch(t, []string{"/orders", "/health"}, mapKeys(paths), "internal operations are hidden")
+		assert.NotContains(t, paths["/orders"], "delete")
+		assert.NotContains(t, spec["paths"], "x-environment", "the original spec is left untouched")
+		assert.Contains(t, spec["paths"], "/admin")
+	})
+
+	t.Run("try it out disabled", func(t *testing.T) {
+		w := get("/swagger/index.html", true)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "supportedSubmitMethods: [],")
+		assert.Contains(t, w.Body.String(), `url: "/swagger.json"`)
+		assert.Equal(t, http.StatusOK, get("/swagger/swagger-ui.css", true).Code)
+	})
+
+	t.Run("mock mode", func(t *testing.T) {
+		w := get("/api/orders", false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"id": 1}]`, w.Body.String())
+		assert.Equal(t, "real", get("/api/health", false).Body.String(), "handlers win over mocks")
+		assert.Equal(t, http.StatusNotFound, get("/api/unknown", false).Code)
+	})
+}
+
+func TestProfileMockHandlerFollowsReloads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	current, version, loads := `{"swagger": "2.0", "paths": {"/a": {"get": {"responses": {"200": {"description": "OK"}}}}}}`, int64(1), 0
+	engine := gin.New()
+	engine.NoRoute(profileMockHandler(func() interface{} {
+		loads++
+		return current
+	}, func() int64 { return version }))
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("/a"))
+	assert.Equal(t, http.StatusNotFound, get("/b"))
+	assert.Equal(t, 1, loads, "the matcher is compiled once per spec version")
+
+	current = `{"swagger": "2.0", "paths": {"/b": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+	version++
+	assert.Equal(t, http.StatusOK, get("/b"))
+	assert.Equal(t, http.StatusNotFound, get("/a"))
+	assert.Equal(t, 2, loads)
+}
+
+func TestProfileDefaultScheme(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	require.NoError(t, Setup(router, quietConfig().WithEnvironment("staging")))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"schemes":["https"]`)
+	assert.Contains(t, w.Body.String(), `"x-environment":"staging"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+	assert.NotContains(t, w.Body.String(), "supportedSubmitMethods", "staging keeps try it out")
+}
+
+func mapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,434 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedValue replaces redacted fields, query values and headers
+const redactedValue = "[REDACTED]"
+
+// RecorderOptions configures the traffic recorder
+type RecorderOptions struct {
+	// SampleRate is the fraction of requests recorded, between 0 and 1 (default: 1, every request)
+	SampleRate float64
+
+	// MaxExamples is the number of latest examples kept per operation (default: 5)
+	MaxExamples int
+
+	// MaxBodyBytes skips bodies larger than this (default: 64 KiB)
+	MaxBodyBytes int
+
+	// RedactFields lists JSON fields, query parameters and request headers whose values are replaced by "[REDACTED]".
+	// Matching is case-insensitive, ignores "_" and "-", and also matches fields containing a name
+	// (e.g., "token" matches "access_token").
+	// Default: password, secret, token, apikey, email
+	RedactFields []string
+
+	// RedactHeaders lists request headers whose values are replaced by "[REDACTED]", in addition
+	// to those matching RedactFields (e.g., X-Auth-Token)
+	// Default: Authorization, Proxy-Authorization, Cookie, X-Api-Key
+	RedactHeaders []string
+
+	// SkipPaths lists route prefixes that are never recorded
+	// Default: "/swagger", "/debug" (the spec, Swagger UI and debug routes)
+	SkipPaths []string
+}
+
+// RecordedExample is a request/response pair captured from live traffic
+type RecordedExample struct {
+	// Method is the upper-case HTTP method
+	Method string `json:"method"`
+
+	// Path is the route template in spec form, including any basePath (e.g., "/api/users/{id}")
+	Path string `json:"path"`
+
+	// RequestPath is the actual request path (e.g., "/api/users/42")
+	RequestPath string `json:"requestPath"`
+
+	// Query is the redacted query string
+	Query string `json:"query,omitempty"`
+
+	// RequestHeaders holds the first value of each request header, redacted
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+
+	// RequestBody is the decoded JSON request body, redacted
+	RequestBody interface{} `json:"requestBody,omitempty"`
+
+	// Status is the response status code
+	Status int `json:"status"`
+
+	// ResponseBody is the decoded JSON response body, redacted
+	ResponseBody interface{} `json:"responseBody,omitempty"`
+
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Recorder samples live traffic per route and keeps the latest examples in memory.
+// It records every gin route, documented in the spec or not.
+type Recorder struct {
+	options       RecorderOptions
+	redactFields  []string
+	redactHeaders map[string]bool
+
+	mu       sync.Mutex
+	examples map[string][]RecordedExample
+}
+
+// NewRecorder creates a traffic recorder. Add its Middleware to the router and set it on
+// Config.Recorder to serve the recorded examples in the spec.
+//
+// Example:
+//
+//	recorder, err := swagger.NewRecorder(swagger.RecorderOptions{SampleRate: 0.1})
+//	router.Use(recorder.Middleware())
+//	swagger.SetupWithSwag(router, swagSpec, swagger.DefaultConfig().WithRecorder(recorder))
+//	router.GET("/debug/swagger/examples", recorder.Handler())
+func NewRecorder(options RecorderOptions) (*Recorder, error) {
+	if options.SampleRate < 0 || options.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1, got %v", options.SampleRate)
+	}
+	if options.SampleRate == 0 {
+		options.SampleRate = 1
+	}
+	if options.MaxExamples <= 0 {
+		options.MaxExamples = 5
+	}
+	if options.MaxBodyBytes <= 0 {
+		options.MaxBodyBytes = 64 << 10
+	}
+	if options.RedactFields == nil {
+		options.RedactFields = []string{"password", "secret", "token", "apikey", "email"}
+	}
+	if options.SkipPaths == nil {
+		options.SkipPaths = []string{"/swagger", "/debug"}
+	}
+	if options.RedactHeaders == nil {
+		options.RedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+	}
+
+	r := &Recorder{
+		options:       options,
+		redactHeaders: make(map[string]bool),
+		examples:      make(map[string][]RecordedExample),
+	}
+	for _, field := range options.RedactFields {
+		r.redactFields = append(r.redactFields, normalizeFieldName(field))
+	}
+	for _, header := range options.RedactHeaders {
+		r.redactHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+	return r, nil
+}
+
+// Middleware records sampled requests to known routes. Unsampled requests are passed through untouched.
+func (r *Recorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if r.skips(c.FullPath()) || (r.options.SampleRate < 1 && rand.Float64() >= r.options.SampleRate) {
+			c.Next()
+			return
+		}
+
+		example := RecordedExample{
+			Method:         c.Request.Method,
+			Path:           ginPathToSpec(c.FullPath()),
+			RequestPath:    c.Request.URL.Path,
+			Query:          r.redactQuery(c.Request.URL.Query()),
+			RequestHeaders: r.redactHeaderValues(c.Request.Header),
+		}
+		if isJSONContentType(c.Request.Header.Get("Content-Type")) && c.Request.Body != nil {
+			data, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(r.options.MaxBodyBytes)+1))
+			// Hand the handler the full body, including what was read
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(data), c.Request.Body), c.Request.Body}
+			if err == nil && len(data) <= r.options.MaxBodyBytes {
+				example.RequestBody = r.decode(data)
+			}
+		}
+
+		writer := &recordingResponseWriter{ResponseWriter: c.Writer, limit: r.options.MaxBodyBytes}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		example.Status = writer.Status()
+		if isJSONContentType(writer.Header().Get("Content-Type")) && !writer.truncated {
+			example.ResponseBody = r.decode(writer.body.Bytes())
+		}
+		example.RecordedAt = time.Now().UTC()
+		r.add(example)
+	}
+}
+
+// skips reports whether a route is unknown or excluded by SkipPaths
+func (r *Recorder) skips(fullPath string) bool {
+	if fullPath == "" {
+		return true
+	}
+	for _, prefix := range r.options.SkipPaths {
+		if strings.HasPrefix(fullPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// add stores an example, dropping the oldest one of its route beyond MaxExamples
+func (r *Recorder) add(example RecordedExample) {
+	key := example.Method + " " + example.Path
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	examples := append(r.examples[key], example)
+	if len(examples) > r.options.MaxExamples {
+		examples = examples[len(examples)-r.options.MaxExamples:]
+	}
+	r.examples[key] = examples
+}
+
+// Examples returns the recorded examples sorted by path, then method, oldest first
+func (r *Recorder) Examples() []RecordedExample {
+	r.mu.Lock()
+	keys := make([]string, 0, len(r.examples))
+	for key := range r.examples {
+		keys = append(keys, key)
+	}
+	var out []RecordedExample
+	sort.Slice(keys, func(i, j int) bool {
+		mi, pi, _ := strings.Cut(keys[i], " ")
+		mj, pj, _ := strings.Cut(keys[j], " ")
+		if pi != pj {
+			return pi < pj
+		}
+		return mi < mj
+	})
+	for _, key := range keys {
+		out = append(out, r.examples[key]...)
+	}
+	r.mu.Unlock()
+	return out
+}
+
+// Reset drops every recorded example
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.examples = make(map[string][]RecordedExample)
+	r.mu.Unlock()
+}
+
+// Export returns the recorded examples as indented JSON, ready to be committed and loaded
+// back with LoadRecordedExamples
+func (r *Recorder) Export() ([]byte, error) {
+	examples := r.Examples()
+	if examples == nil {
+		examples = []RecordedExample{}
+	}
+	data, err := json.MarshalIndent(examples, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recorded examples: %w", err)
+	}
+	return data, nil
+}
+
+// Handler serves the Export output
+func (r *Recorder) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := r.Export()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	}
+}
+
+// LoadRecordedExamples parses examples written by Recorder.Export
+func LoadRecordedExamples(data []byte) ([]RecordedExample, error) {
+	var examples []RecordedExample
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("invalid recorded examples: %w", err)
+	}
+	return examples, nil
+}
+
+// MergeExamples returns a copy of a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+// with recorded examples merged into the documented operations: the latest response body of each
+// documented status becomes the response's "application/json" example, and the latest request
+// body becomes the body parameter's x-example. Examples of undocumented routes or statuses are skipped.
+func MergeExamples(spec interface{}, examples []RecordedExample) (map[string]interface{}, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("merging examples supports Swagger 2.0 specs only")
+	}
+
+	out := shallowCopy(specMap)
+	paths, _ := cloneValue(specMap["paths"]).(map[string]interface{})
+	out["paths"] = paths
+	basePath, _ := specMap["basePath"].(string)
+	basePath = strings.TrimSuffix(basePath, "/")
+
+	ops := make(map[string]specOperation)
+	for _, op := range specOperations(out) {
+		ops[operationKey(op)] = op
+	}
+
+	// Later examples win, so the latest recording ends up in the spec
+	for _, example := range examples {
+		path := strings.TrimPrefix(example.Path, basePath)
+		op, ok := ops[strings.ToLower(example.Method)+" "+pathParamPattern.ReplaceAllString(path, "{}")]
+		if !ok {
+			continue
+		}
+
+		if example.RequestBody != nil {
+			params, _ := op.Operation["parameters"].([]interface{})
+			for _, param := range params {
+				if p, ok := param.(map[string]interface{}); ok && p["in"] == "body" {
+					p["x-example"] = cloneValue(example.RequestBody)
+				}
+			}
+		}
+
+		responses, _ := op.Operation["responses"].(map[string]interface{})
+		response, ok := responses[fmt.Sprint(example.Status)].(map[string]interface{})
+		if !ok || example.ResponseBody == nil {
+			continue
+		}
+		if _, isRef := response["$ref"]; isRef {
+			continue
+		}
+		response["examples"] = map[string]interface{}{"application/json": cloneValue(example.ResponseBody)}
+	}
+	return out, nil
+}
+
+// decode parses a JSON body and redacts it, returning nil for invalid JSON
+func (r *Recorder) decode(data []byte) interface{} {
+	var value interface{}
+	if len(bytes.TrimSpace(data)) == 0 || json.Unmarshal(data, &value) != nil {
+		return nil
+	}
+	return r.redact(value)
+}
+
+// redact replaces the values of redacted fields in place
+func (r *Recorder) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if r.redactsField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = r.redact(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redact(item)
+		}
+	}
+	return value
+}
+
+// redactsField reports whether a field name contains one of the redacted names
+func (r *Recorder) redactsField(name string) bool {
+	normalized := normalizeFieldName(name)
+	for _, field := range r.redactFields {
+		if strings.Contains(normalized, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactQuery encodes query parameters with redacted values replaced
+func (r *Recorder) redactQuery(query url.Values) string {
+	for name, values := range query {
+		if r.redactsField(name) {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return query.Encode()
+}
+
+// redactHeaderValues keeps the first value of each header, replacing redacted ones
+func (r *Recorder) redactHeaderValues(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		if r.redactHeaders[http.CanonicalHeaderKey(name)] || r.redactsField(name) {
+			out[name] = redactedValue
+		} else {
+			out[name] = values[0]
+		}
+	}
+	return out
+}
+
+// normalizeFieldName lower-cases a field name and drops "_" and "-" ("API_Key" becomes "apikey")
+func normalizeFieldName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// isJSONContentType reports whether a Content-Type header value carries JSON
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && isJSONMediaType(mediaType)
+}
+
+// readCloser pairs a reader with the closer of the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recordingResponseWriter copies the response body up to a limit while writing it through
+type recordingResponseWriter struct {
+	gin.ResponseWriter
+	limit     int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingResponseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// capture keeps data until the body exceeds the limit
+func (w *recordingResponseWriter) capture(data []byte) {
+	if w.truncated {
+		return
+	}
+	if w.body.Len()+len(data) > w.limit {
+		w.truncated = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
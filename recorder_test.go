@@ -0,0 +1,189 @@
+package swagger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recorderSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/users": {
+			"post": {
+				"parameters": [{"name": "body", "in": "body", "schema": {"type": "object"}}],
+				"responses": {"201": {"description": "Created", "schema": {"type": "object"}}}
+			}
+		},
+		"/users/{userId}": {
+			"get": {
+				"parameters": [{"name": "userId", "in": "path", "type": "integer", "required": true}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "object"}}}
+			}
+		}
+	}
+}`
+
+func newRecorderRouter(t *testing.T, options RecorderOptions) (*gin.Engine, *Recorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder, err := NewRecorder(options)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(recorder.Middleware())
+	router.POST("/api/users", func(c *gin.Context) {
+		var body map[string]interface{}
+		require.NoError(t, c.ShouldBindJSON(&body), "the handler still reads the body")
+		body["id"] = 1
+		c.JSON(http.StatusCreated, body)
+	})
+	router.GET("/api/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "name": "Jane", "email": "jane@example.com"})
+	})
+	router.GET("/api/legacy", func(c *gin.Context) {
+		c.String(http.StatusOK, "plain")
+	})
+	return router, recorder
+}
+
+func TestRecorderCapturesTraffic(t *testing.T) {
+	router, recorder := newRecorderRouter(t, RecorderOptions{})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/users?access_token=abc&page=2",
+		strings.NewReader(`{"name": "Jane", "password": "hunter2", "profile": {"apiKey": "k"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("X-Auth-Token", "t0k3n")
+	req.Header.Set("X-API-Key", "k3y")
+	req.Header.Set("X-Session-Secret", "s3cr3t")
+	req.Header.Set("X-Request-ID", "42")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"password":"hunter2"`, "the response itself is not redacted")
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/missing", nil))
+
+	examples := recorder.Examples()
+	require.Len(t, examples, 1, "unknown routes are not recorded")
+	example := examples[0]
+	assert.Equal(t, "POST", example.Method)
+	assert.Equal(t, "/api/users", example.Path)
+	assert.Equal(t, "access_token=%5BREDACTED%5D&page=2", example.Query)
+	assert.Equal(t, redactedValue, example.RequestHeaders["Authorization"])
+	assert.Equal(t, redactedValue, example.RequestHeaders["X-Auth-Token"], "header names match the redacted fields")
+	assert.Equal(t, redactedValue, example.RequestHeaders["X-Api-Key"])
+	assert.Equal(t, redactedValue, example.RequestHeaders["X-Session-Secret"])
+	assert.Equal(t, "42", example.RequestHeaders["X-Request-Id"])
+	assert.Equal(t, map[string]interface{}{
+		"name":     "Jane",
+		"password": redactedValue,
+		"profile":  map[string]interface{}{"apiKey": redactedValue},
+	}, example.RequestBody)
+	assert.Equal(t, http.StatusCreated, example.Status)
+	assert.Equal(t, float64(1), example.ResponseBody.(map[string]interface{})["id"])
+}
+
+func TestRecorderKeepsLatestExamples(t *testing.T) {
+	router, recorder := newRecorderRouter(t, RecorderOptions{MaxExamples: 2})
+
+	for _, id := range []string{"1", "2", "3"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/"+id, nil))
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/legacy", nil))
+
+	examples := recorder.Examples()
+	require.Len(t, examples, 3)
+	assert.Equal(t, "/api/legacy", examples[0].Path)
+	assert.Nil(t, examples[0].ResponseBody, "non-JSON bodies are not kept")
+	assert.Equal(t, "/api/users/2", examples[1].RequestPath)
+	assert.Equal(t, "/api/users/3", examples[2].RequestPath)
+	assert.Equal(t, "/api/users/{id}", examples[2].Path)
+	assert.Equal(t, redactedValue, examples[2].ResponseBody.(map[string]interface{})["email"])
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Examples())
+}
+
+func TestRecorderSkipsLargeBodies(t *testing.T) {
+	router, recorder := newRecorderRouter(t, RecorderOptions{MaxBodyBytes: 16})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"name": "a long enough name"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	examples := recorder.Examples()
+	require.Len(t, examples, 1)
+	assert.Nil(t, examples[0].RequestBody)
+	assert.Nil(t, examples[0].ResponseBody)
+}
+
+func TestMergeExamples(t *testing.T) {
+	router, recorder := newRecorderRouter(t, RecorderOptions{})
+	for _, id := range []string{"1", "2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/"+id, nil))
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"name": "Jane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	data, err := recorder.Export()
+	require.NoError(t, err)
+	examples, err := LoadRecordedExamples(data)
+	require.NoError(t, err)
+	require.Len(t, examples, 3)
+
+	spec, err := MergeExamples(recorderSpec, examples)
+	require.NoError(t, err)
+
+	paths := spec["paths"].(map[string]interface{})
+	get := paths["/users/{userId}"].(map[string]interface{})["get"].(map[string]interface{})
+	response := get["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"application/json": map[string]interface{}{"id": "2", "name": "Jane", "email": redactedValue},
+	}, response["examples"], "the latest example wins")
+
+	post := paths["/users"].(map[string]interface{})["post"].(map[string]interface{})
+	body := post["parameters"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Jane"}, body["x-example"])
+
+	requests, err := ExampleRequests(spec, 1)
+	require.NoError(t, err)
+	sent, _ := io.ReadAll(requests[0].Request.Body)
+	assert.JSONEq(t, `{"name": "Jane"}`, string(sent), "example requests use the recorded body")
+}
+
+func TestRecorderServedSpec(t *testing.T) {
+	router, recorder := newRecorderRouter(t, RecorderOptions{})
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(recorderSpec), &spec))
+	require.NoError(t, SetupWithSwag(router, spec, DefaultConfig().WithRecorder(recorder)))
+	router.GET("/debug/swagger/examples", recorder.Handler())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/7", nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"examples":{"application/json":{"email":"[REDACTED]","id":"7","name":"Jane"}}`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/swagger/examples", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"requestPath": "/api/users/7"`)
+	assert.NotContains(t, w.Body.String(), "/swagger.json", "the spec routes are skipped")
+}
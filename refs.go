@@ -0,0 +1,332 @@
+package swagger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCircularRef is returned when dereferencing a spec whose $refs form a cycle
+var ErrCircularRef = errors.New("circular $ref")
+
+// refMode selects how a refResolver rewrites references
+type refMode int
+
+const (
+	// bundleMode internalises external refs under #/definitions
+	bundleMode refMode = iota
+	// dereferenceMode inlines every ref
+	dereferenceMode
+)
+
+// refResolver walks a spec and rewrites its $refs
+type refResolver struct {
+	fsys fs.FS
+	mode refMode
+	root map[string]interface{}
+
+	// docs caches external documents by their path in fsys
+	docs map[string]interface{}
+	// bundled maps an external "file#pointer" to its name under #/definitions
+	bundled map[string]string
+	// definitions collects definitions added while bundling
+	definitions map[string]interface{}
+	// inlined caches dereferenced targets by "file#pointer"
+	inlined map[string]interface{}
+}
+
+// Bundle returns a copy of spec in which every external ref (e.g., "models.yaml#/User")
+// is copied into #/definitions and replaced by an internal ref.
+// Files are read from fsys relative to its root; circular refs are allowed.
+//
+// Example:
+//
+//	bundled, err := swagger.Bundle(swagSpec, os.DirFS("docs"))
+func Bundle(spec interface{}, fsys fs.FS) (map[string]interface{}, error) {
+	return resolveRefs(spec, fsys, bundleMode)
+}
+
+// Dereference returns a copy of spec in which every $ref, internal or external, is inlined.
+// It fails with ErrCircularRef when refs form a cycle, since the result could not be represented.
+//
+// Example:
+//
+//	flat, err := swagger.Dereference(swagSpec, nil)
+//	if errors.Is(err, swagger.ErrCircularRef) {
+//	    // fall back to Bundle
+//	}
+func Dereference(spec interface{}, fsys fs.FS) (map[string]interface{}, error) {
+	return resolveRefs(spec, fsys, dereferenceMode)
+}
+
+// ResolveRef returns the value an internal ref (e.g., "#/definitions/User") points to
+func ResolveRef(spec interface{}, ref string) (interface{}, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	file, pointer := splitRef(ref)
+	if file != "" {
+		return nil, fmt.Errorf("unresolvable $ref %q: not an internal reference", ref)
+	}
+	value, err := resolvePointer(specMap, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("unresolvable $ref %q: %w", ref, err)
+	}
+	return value, nil
+}
+
+// resolveRefs runs a refResolver in the given mode over a copy of spec
+func resolveRefs(spec interface{}, fsys fs.FS, mode refMode) (map[string]interface{}, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &refResolver{
+		fsys:        fsys,
+		mode:        mode,
+		root:        specMap,
+		docs:        make(map[string]interface{}),
+		bundled:     make(map[string]string),
+		definitions: make(map[string]interface{}),
+		inlined:     make(map[string]interface{}),
+	}
+
+	out, err := r.walk(specMap, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := out.(map[string]interface{})
+
+	if mode == dereferenceMode {
+		return result, nil
+	}
+
+	if len(r.definitions) > 0 {
+		definitions, _ := result["definitions"].(map[string]interface{})
+		if definitions == nil {
+			definitions = make(map[string]interface{})
+		}
+		for name, def := range r.definitions {
+			definitions[name] = def
+		}
+		result["definitions"] = definitions
+	}
+	return result, nil
+}
+
+// walk copies value, rewriting refs found in it. file is the document value
+// belongs to ("" for the root) and stack holds the refs being expanded.
+func (r *refResolver) walk(value interface{}, file string, stack []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return r.resolve(ref, file, stack)
+		}
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			walked, err := r.walk(val, file, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = walked
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			walked, err := r.walk(val, file, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = walked
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// resolve rewrites a single ref found in file
+func (r *refResolver) resolve(ref, file string, stack []string) (interface{}, error) {
+	refFile, pointer := splitRef(ref)
+	if strings.Contains(refFile, "://") {
+		return nil, fmt.Errorf("unresolvable $ref %q: remote references are not supported", ref)
+	}
+	if refFile != "" {
+		refFile = path.Join(path.Dir(file), refFile)
+	} else {
+		refFile = file
+	}
+	key := refFile + "#" + pointer
+
+	// Internal refs of the root document are already bundled
+	if r.mode == bundleMode && refFile == "" {
+		if _, err := resolvePointer(r.root, pointer); err != nil {
+			return nil, fmt.Errorf("unresolvable $ref %q: %w", ref, err)
+		}
+		return map[string]interface{}{"$ref": "#" + pointer}, nil
+	}
+
+	for _, seen := range stack {
+		if seen == key {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrCircularRef, strings.Join(stack, " -> "), key)
+		}
+	}
+
+	target, err := r.target(refFile, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("unresolvable $ref %q: %w", ref, err)
+	}
+
+	if r.mode == dereferenceMode {
+		if cached, ok := r.inlined[key]; ok {
+			return cloneValue(cached), nil
+		}
+		walked, err := r.walk(target, refFile, append(stack, key))
+		if err != nil {
+			return nil, err
+		}
+		r.inlined[key] = walked
+		return cloneValue(walked), nil
+	}
+
+	if name, ok := r.bundled[key]; ok {
+		return map[string]interface{}{"$ref": "#/definitions/" + escapePointerToken(name)}, nil
+	}
+	name := r.definitionName(refFile, pointer)
+	r.bundled[key] = name
+	// Reserve the name first so refs back to this definition stay internal
+	r.definitions[name] = nil
+	walked, err := r.walk(target, refFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.definitions[name] = walked
+	return map[string]interface{}{"$ref": "#/definitions/" + escapePointerToken(name)}, nil
+}
+
+// target loads the document named file and returns the value at pointer
+func (r *refResolver) target(file, pointer string) (interface{}, error) {
+	if file == "" {
+		return resolvePointer(r.root, pointer)
+	}
+
+	doc, ok := r.docs[file]
+	if !ok {
+		if r.fsys == nil {
+			return nil, fmt.Errorf("no filesystem to read %s from", file)
+		}
+		data, err := fs.ReadFile(r.fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		doc, err = decodeDocument(file, data)
+		if err != nil {
+			return nil, err
+		}
+		r.docs[file] = doc
+	}
+	return resolvePointer(doc, pointer)
+}
+
+// definitionName picks a name under #/definitions for an external ref that does not clash with existing ones
+func (r *refResolver) definitionName(file, pointer string) string {
+	base := strings.TrimSuffix(path.Base(file), path.Ext(file))
+	if tokens := strings.Split(strings.Trim(pointer, "/"), "/"); tokens[len(tokens)-1] != "" {
+		base = unescapePointerToken(tokens[len(tokens)-1])
+	}
+
+	existing, _ := r.root["definitions"].(map[string]interface{})
+	name := base
+	for i := 2; ; i++ {
+		_, inRoot := existing[name]
+		_, added := r.definitions[name]
+		if !inRoot && !added {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// splitRef splits "file.yaml#/a/b" into its file and JSON pointer parts
+func splitRef(ref string) (string, string) {
+	file, pointer, _ := strings.Cut(ref, "#")
+	return file, pointer
+}
+
+// resolvePointer follows a JSON pointer (RFC 6901) inside doc
+func resolvePointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	current := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointerToken(token)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%q not found", token)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("index %q out of range", token)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q", token)
+		}
+	}
+	return current, nil
+}
+
+// escapePointerToken escapes a JSON pointer reference token
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescapePointerToken reverses escapePointerToken
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// decodeDocument parses a JSON or YAML document (chosen by file extension) into JSON-compatible values
+func decodeDocument(name string, data []byte) (interface{}, error) {
+	ext := strings.ToLower(path.Ext(name))
+	if ext != ".yaml" && ext != ".yml" {
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return doc, nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	// Round-trip through JSON so numbers and maps match encoding/json output
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(converted, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package swagger
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const refsSpec = `{
+	"swagger": "2.0",
+	"paths": {
+		"/users": {"get": {"responses": {"200": {"description": "OK", "schema": {"$ref": "models.yaml#/User"}}}}},
+		"/orders": {"get": {"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Order"}}}}}
+	},
+	"definitions": {
+		"Order": {"type": "object", "properties": {"id": {"type": "integer"}}}
+	}
+}`
+
+var refsFS = fstest.MapFS{
+	"models.yaml": {Data: []byte(`
+User:
+  type: object
+  properties:
+    name:
+      type: string
+    address:
+      $ref: "#/Address"
+Address:
+  type: object
+  properties:
+    city:
+      type: string
+`)},
+}
+
+func TestBundle(t *testing.T) {
+	bundled, err := Bundle(refsSpec, refsFS)
+	require.NoError(t, err)
+
+	definitions := bundled["definitions"].(map[string]interface{})
+	assert.Contains(t, definitions, "Order")
+	assert.Contains(t, definitions, "User")
+	assert.Contains(t, definitions, "Address")
+
+	schema, err := ResolveRef(bundled, "#/paths/~1users/get/responses/200/schema")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/User"}, schema)
+
+	address, err := ResolveRef(bundled, "#/definitions/User/properties/address")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/Address"}, address)
+}
+
+func TestDereference(t *testing.T) {
+	t.Run("inlines internal and external refs", func(t *testing.T) {
+		flat, err := Dereference(refsSpec, refsFS)
+		require.NoError(t, err)
+
+		city, err := ResolveRef(flat, "#/paths/~1users/get/responses/200/schema/properties/address/properties/city/type")
+		require.NoError(t, err)
+		assert.Equal(t, "string", city)
+
+		order, err := ResolveRef(flat, "#/paths/~1orders/get/responses/200/schema/type")
+		require.NoError(t, err)
+		assert.Equal(t, "object", order)
+	})
+
+	t.Run("detects circular refs", func(t *testing.T) {
+		spec := `{"definitions": {
+			"Node": {"type": "object", "properties": {"next": {"$ref": "#/definitions/Node"}}}
+		}}`
+
+		_, err := Dereference(spec, nil)
+		assert.True(t, errors.Is(err, ErrCircularRef))
+
+		_, err = Bundle(spec, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports dangling refs", func(t *testing.T) {
+		_, err := Dereference(`{"definitions": {"A": {"$ref": "#/definitions/Missing"}}}`, nil)
+		assert.ErrorContains(t, err, `unresolvable $ref "#/definitions/Missing"`)
+	})
+}
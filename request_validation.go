@@ -0,0 +1,531 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Violation is a single problem found in a request or response
+type Violation struct {
+	// In is where the problem is: "path", "query", "header", "formData", "body" or "response"
+	In string `json:"in"`
+
+	// Name is the parameter name, empty for bodies
+	Name string `json:"name,omitempty"`
+
+	// Pointer locates the problem inside a JSON body
+	Pointer string `json:"pointer,omitempty"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// ProblemDetails is an RFC 7807 problem response listing every violation
+type ProblemDetails struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	Errors   []Violation `json:"errors,omitempty"`
+}
+
+// routeOperation is an operation together with the pattern matching its request paths
+type routeOperation struct {
+	specOperation
+	key     string
+	pattern *regexp.Regexp
+	names   []string
+}
+
+// compiledOperation holds the prepared parameter and body checks of one operation
+type compiledOperation struct {
+	params []compiledParam
+	body   *compiledParam
+}
+
+// compiledParam is a parameter with its schema extracted
+type compiledParam struct {
+	name             string
+	in               string
+	required         bool
+	collectionFormat string
+	schema           map[string]interface{}
+
+	// file marks formData parameters of type file, checked against multipart file parts
+	file bool
+}
+
+// operationMatcher finds the spec operation serving a request
+type operationMatcher struct {
+	spec     map[string]interface{}
+	basePath string
+	routes   []routeOperation
+	byKey    map[string]*routeOperation
+}
+
+// DefaultMaxBodySize is the largest request body RequestValidator reads, 10 MiB
+const DefaultMaxBodySize int64 = 10 << 20
+
+// RequestValidator checks incoming requests against the operations of a spec
+type RequestValidator struct {
+	*operationMatcher
+
+	// compiled caches compiledOperation values by operation key
+	compiled sync.Map
+
+	// maxBodySize is the largest body read, see WithMaxBodySize
+	maxBodySize int64
+}
+
+// newOperationMatcher indexes the operations of a Swagger 2.0 spec by method and path template
+func newOperationMatcher(spec interface{}) (*operationMatcher, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	if isOpenAPI3(specMap) {
+		return nil, fmt.Errorf("only Swagger 2.0 specs are supported")
+	}
+
+	basePath, _ := specMap["basePath"].(string)
+	m := &operationMatcher{
+		spec:     specMap,
+		basePath: strings.TrimSuffix(basePath, "/"),
+		byKey:    make(map[string]*routeOperation),
+	}
+	for _, op := range specOperations(specMap) {
+		pattern, names := pathTemplatePattern(op.Path)
+		m.routes = append(m.routes, routeOperation{
+			specOperation: op,
+			key:           operationKey(op),
+			pattern:       pattern,
+			names:         names,
+		})
+	}
+	for i := range m.routes {
+		m.byKey[m.routes[i].key] = &m.routes[i]
+	}
+	return m, nil
+}
+
+// NewRequestValidator prepares request validation for a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+func NewRequestValidator(spec interface{}) (*RequestValidator, error) {
+	matcher, err := newOperationMatcher(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestValidator{operationMatcher: matcher, maxBodySize: DefaultMaxBodySize}, nil
+}
+
+// WithMaxBodySize sets the largest request body read for validation, DefaultMaxBodySize when
+// n is not positive. The middleware answers larger bodies with 413 Request Entity Too Large.
+func (v *RequestValidator) WithMaxBodySize(n int64) *RequestValidator {
+	if n <= 0 {
+		n = DefaultMaxBodySize
+	}
+	v.maxBodySize = n
+	return v
+}
+
+// RequestValidation returns a gin middleware that rejects requests not matching the spec.
+//
+// The route is matched to an operation, then path, query, header and formData parameters
+// and the JSON body are checked against their schemas (types, required fields, enums,
+// formats, min/max, patterns). Invalid requests get a 400 application/problem+json
+// response listing every violation, and bodies over DefaultMaxBodySize a 413 (see
+// RequestValidator.WithMaxBodySize). Requests to undocumented routes pass through.
+//
+// Example:
+//
+//	validation, err := swagger.RequestValidation(swagSpec)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	api := router.Group("/api", validation)
+func RequestValidation(spec interface{}) (gin.HandlerFunc, error) {
+	v, err := NewRequestValidator(spec)
+	if err != nil {
+		return nil, err
+	}
+	return v.Middleware(), nil
+}
+
+// Middleware returns the gin middleware
+func (v *RequestValidator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, params := v.match(c.Request.Method, c.FullPath(), c.Request.URL.Path)
+		if route == nil {
+			c.Next()
+			return
+		}
+
+		violations, tooLarge := v.validateRequest(c.Writer, c.Request, route, params)
+		if len(violations) == 0 {
+			c.Next()
+			return
+		}
+
+		status := http.StatusBadRequest
+		if tooLarge {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeProblem(c, ProblemDetails{
+			Type:     "about:blank",
+			Title:    "Request validation failed",
+			Status:   status,
+			Detail:   fmt.Sprintf("%d violation(s) of %s %s", len(violations), strings.ToUpper(route.Method), route.Path),
+			Instance: c.Request.URL.Path,
+			Errors:   violations,
+		})
+	}
+}
+
+// ValidateRequest checks a request and returns every violation. Requests to undocumented routes have none.
+func (v *RequestValidator) ValidateRequest(r *http.Request) []Violation {
+	route, params := v.match(r.Method, "", r.URL.Path)
+	if route == nil {
+		return nil
+	}
+	violations, _ := v.validateRequest(nil, r, route, params)
+	return violations
+}
+
+// match finds the operation for a request, preferring the gin route template when known
+func (m *operationMatcher) match(method, fullPath, requestPath string) (*routeOperation, map[string]string) {
+	method = strings.ToLower(method)
+	relative, ok := trimBasePath(requestPath, m.basePath)
+	if !ok {
+		return nil, nil
+	}
+
+	if template, ok := trimBasePath(fullPath, m.basePath); ok && fullPath != "" {
+		template = ginPathToSpec(template)
+		key := method + " " + pathParamPattern.ReplaceAllString(template, "{}")
+		if route, ok := m.byKey[key]; ok {
+			return route, route.params(relative)
+		}
+	}
+
+	for i := range m.routes {
+		route := &m.routes[i]
+		if route.Method == method && route.pattern.MatchString(relative) {
+			return route, route.params(relative)
+		}
+	}
+	return nil, nil
+}
+
+// trimBasePath strips basePath from a path when it ends on a segment boundary, so /api matches /api/users but not /apiv2/users
+func trimBasePath(path, basePath string) (string, bool) {
+	if !strings.HasPrefix(path, basePath) {
+		return path, false
+	}
+	relative := path[len(basePath):]
+	if relative != "" && relative[0] != '/' {
+		return path, false
+	}
+	return relative, true
+}
+
+// params extracts path parameter values from a request path
+func (r *routeOperation) params(path string) map[string]string {
+	values := make(map[string]string, len(r.names))
+	match := r.pattern.FindStringSubmatch(path)
+	for i, name := range r.names {
+		if i+1 < len(match) {
+			values[name], _ = url.PathUnescape(match[i+1])
+		}
+	}
+	return values
+}
+
+// operation returns the compiled checks of an operation, compiling them on first use
+func (v *RequestValidator) operation(route *routeOperation) *compiledOperation {
+	if cached, ok := v.compiled.Load(route.key); ok {
+		return cached.(*compiledOperation)
+	}
+
+	compiled := &compiledOperation{}
+	for _, param := range route.parameters(v.spec) {
+		p := compiledParam{
+			name:     fmt.Sprint(param["name"]),
+			in:       fmt.Sprint(param["in"]),
+			required: param["required"] == true,
+		}
+		if p.in == "body" {
+			p.schema, _ = param["schema"].(map[string]interface{})
+			compiled.body = &p
+			continue
+		}
+		p.collectionFormat, _ = param["collectionFormat"].(string)
+		p.file = param["type"] == "file"
+		p.schema = parameterSchema(param)
+		compiled.params = append(compiled.params, p)
+	}
+
+	actual, _ := v.compiled.LoadOrStore(route.key, compiled)
+	return actual.(*compiledOperation)
+}
+
+// validateRequest checks parameters and body of a matched request. A body over the size limit
+// is the only violation reported, with tooLarge set; w may be nil outside of a handler.
+func (v *RequestValidator) validateRequest(w http.ResponseWriter, r *http.Request, route *routeOperation, pathValues map[string]string) (violations []Violation, tooLarge bool) {
+	op := v.operation(route)
+
+	var body []byte
+	if r.Body != nil {
+		original := r.Body
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(w, original, v.maxBodySize))
+		var maxBytes *http.MaxBytesError
+		if errors.As(err, &maxBytes) {
+			// The body is left partly read; the request cannot be handled as sent
+			message := fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytes.Limit)
+			return []Violation{{In: "body", Message: message}}, true
+		}
+		original.Close()
+		// Restore the body for the handler
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	query := r.URL.Query()
+	var form *requestForm
+	for _, param := range op.params {
+		var raw []string
+		switch param.in {
+		case "path":
+			if value, ok := pathValues[param.name]; ok {
+				raw = []string{value}
+			}
+		case "query":
+			raw = query[param.name]
+		case "header":
+			raw = r.Header.Values(param.name)
+		case "formData":
+			if form == nil {
+				var err error
+				if form, err = parseRequestForm(r.Header.Get("Content-Type"), body, v.maxBodySize); err != nil {
+					violations = append(violations, Violation{In: "formData", Message: err.Error()})
+				}
+			}
+			if param.file {
+				if len(form.files[param.name]) == 0 && param.required {
+					violations = append(violations, Violation{In: "formData", Name: param.name, Message: "parameter is required"})
+				}
+				continue
+			}
+			raw = form.values[param.name]
+		}
+		violations = append(violations, v.validateParam(param, raw)...)
+	}
+
+	if op.body != nil {
+		violations = append(violations, v.validateBody(op.body, r.Header.Get("Content-Type"), body)...)
+	}
+	return violations, false
+}
+
+// requestForm holds the formData fields and uploaded files of a request body
+type requestForm struct {
+	values url.Values
+	files  map[string][]*multipart.FileHeader
+}
+
+// parseRequestForm reads a multipart/form-data or application/x-www-form-urlencoded body.
+// Other bodies have no form fields. The form is empty, not nil, when an error is returned.
+func parseRequestForm(contentType string, body []byte, maxMemory int64) (*requestForm, error) {
+	form := &requestForm{values: url.Values{}}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return form, nil
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return form, fmt.Errorf("request body is not valid %s: %v", mediaType, err)
+		}
+		form.values = values
+	case "multipart/form-data":
+		parsed, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(maxMemory)
+		if err != nil {
+			return form, fmt.Errorf("request body is not valid %s: %v", mediaType, err)
+		}
+		// Only the part headers are kept; files spilled to disk are not needed
+		_ = parsed.RemoveAll()
+		form.values = parsed.Value
+		form.files = parsed.File
+	}
+	return form, nil
+}
+
+// validateParam coerces raw string values of a parameter and validates them against its schema
+func (v *RequestValidator) validateParam(param compiledParam, raw []string) []Violation {
+	if len(raw) == 0 || (len(raw) == 1 && raw[0] == "" && param.in != "path") {
+		if param.required {
+			return []Violation{{In: param.in, Name: param.name, Message: "parameter is required"}}
+		}
+		return nil
+	}
+
+	value, err := coerceParam(param.schema, param.collectionFormat, raw)
+	if err != nil {
+		return []Violation{{In: param.in, Name: param.name, Message: err.Error()}}
+	}
+
+	var violations []Violation
+	for _, e := range validateValue(v.spec, param.schema, value, "") {
+		violations = append(violations, Violation{In: param.in, Name: param.name, Pointer: e.Pointer, Message: e.Message})
+	}
+	return violations
+}
+
+// validateBody parses a JSON body and validates it against the body parameter schema
+func (v *RequestValidator) validateBody(param *compiledParam, contentType string, body []byte) []Violation {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if param.required {
+			return []Violation{{In: "body", Message: "request body is required"}}
+		}
+		return nil
+	}
+	if contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && !isJSONMediaType(mediaType) {
+			// Only JSON bodies can be checked against a schema
+			return nil
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []Violation{{In: "body", Message: "request body is not valid JSON: " + err.Error()}}
+	}
+
+	var violations []Violation
+	for _, e := range validateValue(v.spec, param.schema, value, "") {
+		violations = append(violations, Violation{In: "body", Pointer: e.Pointer, Message: e.Message})
+	}
+	return violations
+}
+
+// parameterSchema builds a schema from the type keywords of a non-body parameter
+func parameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{})
+	for key, value := range param {
+		switch key {
+		case "name", "in", "required", "description", "collectionFormat", "allowEmptyValue":
+		default:
+			schema[key] = value
+		}
+	}
+	if schema["type"] == "file" {
+		// Uploaded files are not inspected
+		return map[string]interface{}{}
+	}
+	return schema
+}
+
+// coerceParam converts raw string values into the JSON type declared by the schema
+func coerceParam(schema map[string]interface{}, collectionFormat string, raw []string) (interface{}, error) {
+	if schema["type"] == "array" {
+		var parts []string
+		if collectionFormat == "multi" {
+			parts = raw
+		} else {
+			separator := map[string]string{"ssv": " ", "tsv": "\t", "pipes": "|"}[collectionFormat]
+			if separator == "" {
+				separator = ","
+			}
+			parts = strings.Split(raw[0], separator)
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		values := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			value, err := coerceScalar(items, part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	return coerceScalar(schema, raw[0])
+}
+
+// coerceScalar converts a single string value into the JSON type declared by the schema
+func coerceScalar(schema map[string]interface{}, raw string) (interface{}, error) {
+	switch schema["type"] {
+	case "integer":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || n != float64(int64(n)) {
+			return nil, fmt.Errorf("value %q is not an integer", raw)
+		}
+		return n, nil
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a number", raw)
+		}
+		return n, nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("value %q is not a boolean", raw)
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+// pathTemplatePattern compiles a spec path template into a regexp and lists its parameter names
+func pathTemplatePattern(path string) (*regexp.Regexp, []string) {
+	var names []string
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, loc := range pathParamPattern.FindAllStringSubmatchIndex(path, -1) {
+		b.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		b.WriteString("([^/]+)")
+		names = append(names, path[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(path[last:]))
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String()), names
+}
+
+// ginPathToSpec converts a gin route ("/users/:id/*path") into a spec path template ("/users/{id}/{path}")
+func ginPathToSpec(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isJSONMediaType reports whether a media type carries JSON
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// writeProblem aborts the request with an RFC 7807 problem response
+func writeProblem(c *gin.Context, problem ProblemDetails) {
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
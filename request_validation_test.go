@@ -0,0 +1,264 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestValidationSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/users/{id}": {
+			"put": {
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer", "minimum": 1},
+					{"name": "notify", "in": "query", "type": "boolean"},
+					{"name": "X-Tenant", "in": "header", "required": true, "type": "string", "pattern": "^[a-z]+$"},
+					{"name": "user", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}
+				],
+				"responses": {"200": {"description": "OK"}}
+			}
+		},
+		"/users": {
+			"get": {
+				"parameters": [
+					{"name": "status", "in": "query", "type": "string", "enum": ["active", "banned"]},
+					{"name": "ids", "in": "query", "type": "array", "items": {"type": "integer"}, "maxItems": 3}
+				],
+				"responses": {"200": {"description": "OK"}}
+			}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"required": ["name", "email"],
+			"properties": {
+				"name": {"type": "string", "minLength": 2},
+				"email": {"type": "string", "format": "email"},
+				"age": {"type": "integer", "maximum": 150}
+			}
+		}
+	}
+}`
+
+func newValidationRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	validation, err := RequestValidation(requestValidationSpec)
+	require.NoError(t, err)
+
+	router := gin.New()
+	api := router.Group("/api", validation)
+	api.PUT("/users/:id", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	})
+	api.GET("/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.GET("/undocumented", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return router
+}
+
+func TestRequestValidation(t *testing.T) {
+	router := newValidationRouter(t)
+
+	t.Run("valid request reaches the handler with its body", func(t *testing.T) {
+		body := `{"name": "Jane", "email": "jane@example.com"}`
+		req := httptest.NewRequest(http.MethodPut, "/api/users/7?notify=true", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, body, w.Body.String())
+	})
+
+	t.Run("invalid request lists every violation", func(t *testing.T) {
+		body := `{"name": "J", "email": "not-an-email", "age": 200}`
+		req := httptest.NewRequest(http.MethodPut, "/api/users/0?notify=maybe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+		var problem ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Request validation failed", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.ElementsMatch(t, []Violation{
+			{In: "path", Name: "id", Message: "value must be at least 1"},
+			{In: "query", Name: "notify", Message: `value "maybe" is not a boolean`},
+			{In: "header", Name: "X-Tenant", Message: "parameter is required"},
+			{In: "body", Pointer: "/age", Message: "value must be at most 150"},
+			{In: "body", Pointer: "/email", Message: "value must be a valid email address"},
+			{In: "body", Pointer: "/name", Message: "length must be at least 2"},
+		}, problem.Errors)
+	})
+
+	t.Run("missing body and required fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/api/users/1", strings.NewReader(`{}`))
+		req.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var problem ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.ElementsMatch(t, []Violation{
+			{In: "body", Message: `missing required property "name"`},
+			{In: "body", Message: `missing required property "email"`},
+		}, problem.Errors)
+	})
+
+	t.Run("query enums and arrays", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/users?status=deleted&ids=1,2,x", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var problem ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.ElementsMatch(t, []Violation{
+			{In: "query", Name: "status", Message: `value must be one of ["active","banned"]`},
+			{In: "query", Name: "ids", Message: `value "x" is not an integer`},
+		}, problem.Errors)
+	})
+
+	t.Run("undocumented routes pass through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/undocumented", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+}
+
+func TestTrimBasePath(t *testing.T) {
+	tests := []struct {
+		path, basePath, want string
+		ok                   bool
+	}{
+		{"/api/users", "/api", "/users", true},
+		{"/api", "/api", "", true},
+		{"/apiv2/users", "/api", "/apiv2/users", false},
+		{"/users", "/api", "/users", false},
+		{"/users", "", "/users", true},
+	}
+	for _, tt := range tests {
+		got, ok := trimBasePath(tt.path, tt.basePath)
+		assert.Equal(t, tt.want, got, tt.path)
+		assert.Equal(t, tt.ok, ok, tt.path)
+	}
+}
+
+func TestRequestValidationMaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator, err := NewRequestValidator(requestValidationSpec)
+	require.NoError(t, err)
+	validator.WithMaxBodySize(64)
+
+	router := gin.New()
+	router.PUT("/api/users/:id", validator.Middleware(), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	})
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/users/7", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"name": "Jane", "email": "jane@example.com"}`
+	w := send(body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, body, w.Body.String(), "bodies within the limit reach the handler")
+
+	w = send(`{"name": "` + strings.Repeat("a", 100) + `", "email": "jane@example.com"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, []Violation{{In: "body", Message: "request body exceeds the limit of 64 bytes"}}, problem.Errors)
+
+	assert.Equal(t, DefaultMaxBodySize, validator.WithMaxBodySize(0).maxBodySize)
+}
+
+func TestRequestValidationFormData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "API", "version": "1.0"},
+		"paths": {
+			"/photos": {
+				"post": {
+					"consumes": ["multipart/form-data", "application/x-www-form-urlencoded"],
+					"parameters": [
+						{"name": "file", "in": "formData", "required": true, "type": "file"},
+						{"name": "title", "in": "formData", "required": true, "type": "string", "minLength": 3}
+					],
+					"responses": {"201": {"description": "Created"}}
+				}
+			}
+		}
+	}`
+	validation, err := RequestValidation(spec)
+	require.NoError(t, err)
+	router := gin.New()
+	router.POST("/photos", validation, func(c *gin.Context) {
+		file, err := c.FormFile("file")
+		require.NoError(t, err, "the handler still reads the upload")
+		c.String(http.StatusCreated, file.Filename+" "+c.PostForm("title"))
+	})
+	send := func(body io.Reader, contentType string) (*httptest.ResponseRecorder, ProblemDetails) {
+		req := httptest.NewRequest(http.MethodPost, "/photos", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var problem ProblemDetails
+		if w.Code != http.StatusCreated {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		}
+		return w, problem
+	}
+	upload := func(withFile bool, title string) (io.Reader, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		if withFile {
+			part, err := writer.CreateFormFile("file", "rex.png")
+			require.NoError(t, err)
+			_, _ = part.Write([]byte("\x89PNG"))
+		}
+		require.NoError(t, writer.WriteField("title", title))
+		require.NoError(t, writer.Close())
+		return &body, writer.FormDataContentType()
+	}
+
+	w, _ := send(upload(true, "Rex"))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "rex.png Rex", w.Body.String())
+
+	_, problem := send(upload(false, "R"))
+	assert.ElementsMatch(t, []Violation{
+		{In: "formData", Name: "file", Message: "parameter is required"},
+		{In: "formData", Name: "title", Message: "length must be at least 3"},
+	}, problem.Errors)
+
+	_, problem = send(strings.NewReader("title=Rex&file=rex.png"), "application/x-www-form-urlencoded")
+	assert.Equal(t, []Violation{{In: "formData", Name: "file", Message: "parameter is required"}}, problem.Errors,
+		"form values do not count as files")
+}
@@ -0,0 +1,250 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseValidationMode decides what happens when a response breaks the contract
+type ResponseValidationMode int
+
+const (
+	// ResponseValidationLog logs violations and sends the response unchanged (default)
+	ResponseValidationLog ResponseValidationMode = iota
+	// ResponseValidationReport only calls OnViolation, e.g. to emit metrics
+	ResponseValidationReport
+	// ResponseValidationFail replaces the response with a 500 application/problem+json response
+	ResponseValidationFail
+)
+
+// ResponseValidationOptions configures response validation
+type ResponseValidationOptions struct {
+	// Mode selects logging, reporting only or failing (default: ResponseValidationLog)
+	Mode ResponseValidationMode
+
+	// SampleRate is the fraction of requests checked, between 0 and 1 (default: 1, every request).
+	// Unsampled requests are not buffered and add no latency.
+	SampleRate float64
+
+	// OnViolation is called with the violations of every invalid response, in any mode
+	OnViolation func(c *gin.Context, violations []Violation)
+
+	// Logger receives violation logs in ResponseValidationLog mode (default: the standard logger)
+	Logger *log.Logger
+}
+
+// ResponseValidator checks outgoing responses against the documented responses of a spec
+type ResponseValidator struct {
+	*operationMatcher
+	options ResponseValidationOptions
+}
+
+// NewResponseValidator prepares response validation for a Swagger 2.0 spec (*Swagger, *SwaggerSpec, swag spec or raw JSON)
+func NewResponseValidator(spec interface{}, options ResponseValidationOptions) (*ResponseValidator, error) {
+	matcher, err := newOperationMatcher(spec)
+	if err != nil {
+		return nil, err
+	}
+	if options.SampleRate < 0 || options.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1, got %v", options.SampleRate)
+	}
+	if options.SampleRate == 0 {
+		options.SampleRate = 1
+	}
+	if options.Logger == nil {
+		options.Logger = log.Default()
+	}
+	return &ResponseValidator{operationMatcher: matcher, options: options}, nil
+}
+
+// ResponseValidation returns a gin middleware that checks responses against the spec.
+//
+// Sampled responses are buffered, then the status code must be documented in the
+// operation's responses (or covered by "default") and JSON bodies must match the
+// response schema. Violations are logged, reported or turned into a 500 depending
+// on the mode. Responses of undocumented routes are not checked.
+//
+// Example:
+//
+//	validation, err := swagger.ResponseValidation(swagSpec, swagger.ResponseValidationOptions{
+//	    SampleRate: 0.1,
+//	    OnViolation: func(c *gin.Context, violations []swagger.Violation) {
+//	        contractViolations.Inc()
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	api := router.Group("/api", validation)
+func ResponseValidation(spec interface{}, options ResponseValidationOptions) (gin.HandlerFunc, error) {
+	v, err := NewResponseValidator(spec, options)
+	if err != nil {
+		return nil, err
+	}
+	return v.Middleware(), nil
+}
+
+// Middleware returns the gin middleware
+func (v *ResponseValidator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v.options.SampleRate < 1 && rand.Float64() >= v.options.SampleRate {
+			c.Next()
+			return
+		}
+		route, _ := v.match(c.Request.Method, c.FullPath(), c.Request.URL.Path)
+		if route == nil {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffer := &bufferedResponseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = original
+
+		violations := v.ValidateResponse(route.Method, route.Path, buffer.status, buffer.Header().Get("Content-Type"), buffer.body.Bytes())
+		if len(violations) == 0 {
+			buffer.flush()
+			return
+		}
+
+		if v.options.OnViolation != nil {
+			v.options.OnViolation(c, violations)
+		}
+		switch v.options.Mode {
+		case ResponseValidationFail:
+			c.Writer.Header().Del("Content-Length")
+			writeProblem(c, ProblemDetails{
+				Type:     "about:blank",
+				Title:    "Response validation failed",
+				Status:   http.StatusInternalServerError,
+				Detail:   fmt.Sprintf("%d violation(s) of %s %s", len(violations), strings.ToUpper(route.Method), route.Path),
+				Instance: c.Request.URL.Path,
+				Errors:   violations,
+			})
+			return
+		case ResponseValidationLog:
+			messages := make([]string, len(violations))
+			for i, violation := range violations {
+				messages[i] = violation.Pointer + " " + violation.Message
+			}
+			v.options.Logger.Printf("swagger: response of %s %s (%d) breaks the contract: %s",
+				c.Request.Method, c.Request.URL.Path, buffer.status, strings.Join(messages, "; "))
+		}
+		buffer.flush()
+	}
+}
+
+// ValidateResponse checks a response of the operation at method and path template (e.g., "get", "/users/{id}").
+// Unknown operations have no violations.
+func (v *ResponseValidator) ValidateResponse(method, path string, status int, contentType string, body []byte) []Violation {
+	route, ok := v.byKey[strings.ToLower(method)+" "+pathParamPattern.ReplaceAllString(path, "{}")]
+	if !ok {
+		return nil
+	}
+
+	responses, _ := route.Operation["responses"].(map[string]interface{})
+	response, ok := responses[strconv.Itoa(status)].(map[string]interface{})
+	if !ok {
+		if response, ok = responses["default"].(map[string]interface{}); !ok {
+			return []Violation{{In: "response", Message: fmt.Sprintf("status %d is not documented", status)}}
+		}
+	}
+	if ref, _ := response["$ref"].(string); ref != "" {
+		if resolved, err := ResolveRef(v.spec, ref); err == nil {
+			response, _ = resolved.(map[string]interface{})
+		}
+	}
+
+	schema, ok := response["schema"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []Violation{{In: "response", Message: fmt.Sprintf("status %d requires a body", status)}}
+	}
+	if contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && !isJSONMediaType(mediaType) {
+			// Only JSON bodies can be checked against a schema
+			return nil
+		}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []Violation{{In: "response", Message: "response body is not valid JSON: " + err.Error()}}
+	}
+
+	var violations []Violation
+	for _, e := range validateValue(v.spec, schema, value, "") {
+		violations = append(violations, Violation{In: "response", Pointer: e.Pointer, Message: e.Message})
+	}
+	return violations
+}
+
+// bufferedResponseWriter holds the status and body of a response until it is flushed.
+// Headers go straight to the wrapped writer's header map, which is only sent on flush.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.written
+}
+
+// Flush is a no-op: streaming is not possible while the response is held back
+func (w *bufferedResponseWriter) Flush() {}
+
+// flush sends the buffered status and body to the wrapped writer
+func (w *bufferedResponseWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,195 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const responseValidationSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "API", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"responses": {
+					"200": {"description": "OK", "schema": {"$ref": "#/definitions/User"}},
+					"404": {"description": "Not found"}
+				}
+			}
+		},
+		"/health": {
+			"get": {
+				"responses": {"default": {"description": "Status", "schema": {"type": "object"}}}
+			}
+		}
+	},
+	"definitions": {
+		"User": {
+			"type": "object",
+			"required": ["id", "name"],
+			"properties": {
+				"id": {"type": "integer"},
+				"name": {"type": "string"}
+			}
+		}
+	}
+}`
+
+func newResponseValidationRouter(t *testing.T, options ResponseValidationOptions, user gin.H, status int) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	validation, err := ResponseValidation(responseValidationSpec, options)
+	require.NoError(t, err)
+
+	router := gin.New()
+	api := router.Group("/api", validation)
+	api.GET("/users/:id", func(c *gin.Context) {
+		c.Header("X-Handler", "users")
+		c.JSON(status, user)
+	})
+	api.GET("/health", func(c *gin.Context) { c.JSON(http.StatusServiceUnavailable, gin.H{"ok": false}) })
+	api.GET("/undocumented", func(c *gin.Context) { c.String(http.StatusTeapot, "short and stout") })
+	return router
+}
+
+func TestResponseValidation(t *testing.T) {
+	t.Run("valid response is sent unchanged", func(t *testing.T) {
+		var reported []Violation
+		router := newResponseValidationRouter(t, ResponseValidationOptions{
+			Mode:        ResponseValidationFail,
+			OnViolation: func(c *gin.Context, violations []Violation) { reported = violations },
+		}, gin.H{"id": 1, "name": "Jane"}, http.StatusOK)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 1, "name": "Jane"}`, w.Body.String())
+		assert.Equal(t, "users", w.Header().Get("X-Handler"))
+		assert.Empty(t, reported)
+	})
+
+	t.Run("fail mode replaces schema mismatches with a problem response", func(t *testing.T) {
+		router := newResponseValidationRouter(t, ResponseValidationOptions{Mode: ResponseValidationFail},
+			gin.H{"id": "one"}, http.StatusOK)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+		var problem ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Response validation failed", problem.Title)
+		pointers := make([]string, 0, len(problem.Errors))
+		for _, violation := range problem.Errors {
+			assert.Equal(t, "response", violation.In)
+			pointers = append(pointers, violation.Pointer)
+		}
+		assert.ElementsMatch(t, []string{"/id", ""}, pointers)
+	})
+
+	t.Run("undocumented status code is reported", func(t *testing.T) {
+		var reported []Violation
+		router := newResponseValidationRouter(t, ResponseValidationOptions{
+			Mode:        ResponseValidationReport,
+			OnViolation: func(c *gin.Context, violations []Violation) { reported = violations },
+		}, gin.H{}, http.StatusConflict)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code, "report mode keeps the response")
+		require.Len(t, reported, 1)
+		assert.Equal(t, "status 409 is not documented", reported[0].Message)
+	})
+
+	t.Run("documented status without schema accepts any body", func(t *testing.T) {
+		var reported []Violation
+		router := newResponseValidationRouter(t, ResponseValidationOptions{
+			Mode:        ResponseValidationFail,
+			OnViolation: func(c *gin.Context, violations []Violation) { reported = violations },
+		}, gin.H{"error": "missing"}, http.StatusNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, reported)
+	})
+
+	t.Run("default response covers other status codes", func(t *testing.T) {
+		router := newResponseValidationRouter(t, ResponseValidationOptions{Mode: ResponseValidationFail}, nil, 0)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"ok": false}`, w.Body.String())
+	})
+
+	t.Run("log mode logs and keeps the response", func(t *testing.T) {
+		var logs bytes.Buffer
+		router := newResponseValidationRouter(t, ResponseValidationOptions{Logger: log.New(&logs, "", 0)},
+			gin.H{"id": 1}, http.StatusOK)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 1}`, w.Body.String())
+		assert.Contains(t, logs.String(), "GET /api/users/1 (200) breaks the contract")
+		assert.Contains(t, logs.String(), `missing required property "name"`)
+	})
+
+	t.Run("undocumented routes are not checked", func(t *testing.T) {
+		router := newResponseValidationRouter(t, ResponseValidationOptions{Mode: ResponseValidationFail}, nil, 0)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/undocumented", nil))
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Equal(t, "short and stout", w.Body.String())
+	})
+}
+
+func TestResponseValidationSampling(t *testing.T) {
+	t.Run("unsampled requests are not checked", func(t *testing.T) {
+		router := newResponseValidationRouter(t, ResponseValidationOptions{
+			Mode:       ResponseValidationFail,
+			SampleRate: 0.000001,
+		}, gin.H{"id": "one"}, http.StatusOK)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid rate is rejected", func(t *testing.T) {
+		_, err := NewResponseValidator(responseValidationSpec, ResponseValidationOptions{SampleRate: 1.5})
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateResponse(t *testing.T) {
+	v, err := NewResponseValidator(responseValidationSpec, ResponseValidationOptions{})
+	require.NoError(t, err)
+
+	assert.Empty(t, v.ValidateResponse("GET", "/users/{userId}", 200, "application/json", []byte(`{"id": 1, "name": "Jane"}`)))
+	assert.Len(t, v.ValidateResponse("GET", "/users/{id}", 200, "application/json", nil), 1)
+	assert.Len(t, v.ValidateResponse("GET", "/users/{id}", 200, "", []byte(`{not json`)), 1)
+	assert.Empty(t, v.ValidateResponse("GET", "/users/{id}", 200, "text/plain", []byte(`hello`)))
+	assert.Empty(t, v.ValidateResponse("POST", "/users/{id}", 500, "", nil))
+}
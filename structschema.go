@@ -0,0 +1,596 @@
+package swagger
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+	// oneofPattern splits the values of a oneof rule, which may be single-quoted to contain spaces
+	oneofPattern = regexp.MustCompile(`'[^']*'|\S+`)
+
+	// typeNamePattern matches the type names in a generic type name, with their package path
+	typeNamePattern = regexp.MustCompile(`[\w./-]+`)
+	localTypeSuffix = regexp.MustCompile(`·\d+`)
+)
+
+// swaggerTagOptions are the options of the swagger:"..." field tag; those marked true take a value
+var swaggerTagOptions = map[string]bool{
+	"description": true,
+	"title":       true,
+	"format":      true,
+	"pattern":     true,
+	"example":     true,
+	"default":     true,
+	"enum":        true,
+	"minimum":     true,
+	"maximum":     true,
+	"minLength":   true,
+	"maxLength":   true,
+	"deprecated":  false,
+	"readOnly":    false,
+	"nullable":    false,
+	"required":    false,
+}
+
+// validatorPatterns are the string rules of go-playground/validator expressible as a pattern
+var validatorPatterns = map[string]string{
+	"alpha":    `^[a-zA-Z]+$`,
+	"alphanum": `^[a-zA-Z0-9]+$`,
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":   `^[0-9]+$`,
+	"e164":     `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// validatorFormats are the string rules of go-playground/validator matching a schema format
+var validatorFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"http_url":         "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// SchemaOf builds the schema of the type of v. Nested structs are inlined; use DefinitionsOf
+// to get named structs as definitions instead. Exported fields are named by their json tag,
+// and their constraints come from these tags:
+//   - example:"..." sets the example, comma-separated for slices
+//   - validate:"..." and binding:"..." take go-playground/validator rules, as enforced by gin's
+//     binding: required, min, max, len, gt, gte, lt, lte, oneof, unique, formats such as email,
+//     url, uuid, ipv4, ipv6 and hostname, patterns such as alpha, alphanum, numeric and e164,
+//     startswith, endswith, contains, datetime and dive for the items of slices and maps
+//   - swagger:"..." takes description=, title=, format=, pattern=, example=, default=, enum=a|b,
+//     minimum=, maximum=, minLength=, maxLength=, deprecated, readOnly, nullable and required,
+//     overriding the rules above; values may contain commas, and swagger:"-" leaves the field out
+//
+// deprecated and nullable are written as x-deprecated and x-nullable, which Swagger 2.0 allows.
+//
+// Example:
+//
+//	type CreateUser struct {
+//	    Email string `json:"email" binding:"required,email"`
+//	    Age   int    `json:"age,omitempty" validate:"min=18,max=130"`
+//	    Role  string `json:"role" validate:"oneof=admin member" swagger:"description=Access level, defaults to member"`
+//	}
+//
+//	schema := swagger.SchemaOf(CreateUser{})
+func SchemaOf(v interface{}) map[string]interface{} {
+	b := &schemaBuilder{building: make(map[reflect.Type]bool)}
+	return b.schema(reflect.TypeOf(v))
+}
+
+// DefinitionsOf builds definitions for the types of values, keyed by type name, for
+// Swagger.SetDefinitions. Named structs they use get their own definitions and are
+// referenced with $ref. See SchemaOf for the supported tags.
+//
+// Generic types are keyed by their name followed by their type arguments, e.g. PageUser for
+// Page[models.User]. A type whose name is already taken by a type of another package is
+// prefixed with its package name (BillingUser), or else numbered (User2).
+//
+// Example:
+//
+//	swagger.SetDefinitions(swagger.DefinitionsOf(User{}, Order{}))
+func DefinitionsOf(values ...interface{}) map[string]interface{} {
+	b := &schemaBuilder{
+		definitions: make(map[string]interface{}),
+		building:    make(map[reflect.Type]bool),
+		names:       make(map[reflect.Type]string),
+		types:       make(map[string]reflect.Type),
+	}
+	for _, v := range values {
+		b.schema(reflect.TypeOf(v))
+	}
+	return b.definitions
+}
+
+// schemaBuilder builds schemas of Go types. With definitions set, named structs are collected
+// there and referenced; otherwise they are inlined.
+type schemaBuilder struct {
+	definitions map[string]interface{}
+	building    map[reflect.Type]bool
+
+	// names and types map named structs to their definition keys and back
+	names map[reflect.Type]string
+	types map[string]reflect.Type
+}
+
+// schema returns the schema of t
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	t = indirectType(t)
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": float64(0)}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes []byte as base64
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		return b.structSchema(t)
+	default:
+		// interfaces hold any value; channels and functions are not serialised
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema returns the schema of a struct, or a reference to its definition
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	var name string
+	if t.Name() != "" && b.definitions != nil {
+		name = b.definitionName(t)
+	}
+	ref := map[string]interface{}{"$ref": "#/definitions/" + escapePointerToken(name)}
+	if name != "" {
+		if _, ok := b.definitions[name]; ok || b.building[t] {
+			return ref
+		}
+	}
+	if b.building[t] {
+		// a recursive type without definitions to refer to
+		return map[string]interface{}{"type": "object"}
+	}
+	b.building[t] = true
+	defer delete(b.building, t)
+
+	properties := make(map[string]interface{})
+	var required []string
+	b.addFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = stringsToInterfaces(required)
+	}
+	if name != "" {
+		b.definitions[name] = schema
+		return ref
+	}
+	return schema
+}
+
+// definitionName returns the definition key of a named struct, unique among the types seen
+func (b *schemaBuilder) definitionName(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	base := typeKey(t.Name())
+	name := base
+	if other, taken := b.types[name]; taken {
+		if other.PkgPath() != t.PkgPath() {
+			name = goName(path.Base(t.PkgPath())) + upperFirst(base)
+		}
+		for i := 2; ; i++ {
+			if _, taken := b.types[name]; !taken {
+				break
+			}
+			name = base + strconv.Itoa(i)
+		}
+	}
+	b.names[t] = name
+	b.types[name] = t
+	return name
+}
+
+// typeKey turns a type name into a definition key, appending the type arguments of generic
+// types without their package path: Page[example.com/models.User] becomes PageUser
+func typeKey(name string) string {
+	var b strings.Builder
+	// types declared in functions have a ·N suffix in type arguments
+	name = localTypeSuffix.ReplaceAllString(name, "")
+	for i, part := range typeNamePattern.FindAllString(name, -1) {
+		part = part[strings.LastIndex(part, "/")+1:]
+		part = part[strings.LastIndex(part, ".")+1:]
+		if i > 0 {
+			part = upperFirst(part)
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// upperFirst upper-cases the first letter of a string
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// addFields adds the properties of the fields of t, including those of embedded structs
+func (b *schemaBuilder) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if field.Anonymous && name == "" && indirectType(field.Type).Kind() == reflect.Struct {
+			// encoding/json promotes the fields of embedded structs
+			b.addFields(indirectType(field.Type), properties, required)
+			continue
+		}
+		if !field.IsExported() || field.Tag.Get("swagger") == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, isRequired := b.fieldSchema(field, options)
+		properties[name] = property
+		if isRequired {
+			*required = appendUnique(*required, name)
+		}
+	}
+}
+
+// fieldSchema returns the schema of a struct field with the constraints of its tags
+func (b *schemaBuilder) fieldSchema(field reflect.StructField, jsonOptions string) (map[string]interface{}, bool) {
+	schema := b.schema(field.Type)
+	if hasJSONOption(jsonOptions, "string") {
+		switch indirectType(field.Type).Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			schema = map[string]interface{}{"type": "string"}
+		}
+	}
+
+	// keywords next to $ref are ignored, so a referenced type is constrained through allOf
+	ref, isRef := schema["$ref"]
+	if isRef {
+		schema = map[string]interface{}{}
+	}
+
+	isRequired := applyValidatorTag(schema, field.Type, field.Tag.Get("binding"))
+	if applyValidatorTag(schema, field.Type, field.Tag.Get("validate")) {
+		isRequired = true
+	}
+	if example, ok := field.Tag.Lookup("example"); ok {
+		schema["example"] = tagValue(example, field.Type)
+	}
+	if applySwaggerTag(schema, field.Type, field.Tag.Get("swagger")) {
+		isRequired = true
+	}
+
+	if isRef {
+		reference := map[string]interface{}{"$ref": ref}
+		if len(schema) == 0 {
+			return reference, isRequired
+		}
+		schema["allOf"] = []interface{}{reference}
+	}
+	return schema, isRequired
+}
+
+// applyValidatorTag maps go-playground/validator rules onto schema keywords and reports
+// whether the field is required. Rules after dive apply to the items of slices and maps;
+// alternatives ("a|b") and rules without a schema equivalent are skipped.
+func applyValidatorTag(schema map[string]interface{}, t reflect.Type, tag string) (required bool) {
+	if tag == "" || tag == "-" {
+		return false
+	}
+	target, targetType, diving := schema, indirectType(t), false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if strings.Contains(rule, "|") {
+			continue
+		}
+		switch name {
+		case "required":
+			// required after dive only rejects empty items, which a schema cannot express
+			required = required || !diving
+		case "dive":
+			var items map[string]interface{}
+			switch targetType.Kind() {
+			case reflect.Slice, reflect.Array:
+				items, _ = target["items"].(map[string]interface{})
+			case reflect.Map:
+				items, _ = target["additionalProperties"].(map[string]interface{})
+			}
+			if items == nil {
+				// later rules apply to something without a schema of its own
+				return required
+			}
+			target, targetType, diving = items, indirectType(targetType.Elem()), true
+		default:
+			applyValidatorRule(target, targetType, name, param)
+		}
+	}
+	return required
+}
+
+// applyValidatorRule maps a single validator rule onto schema keywords
+func applyValidatorRule(schema map[string]interface{}, t reflect.Type, name, param string) {
+	if format, ok := validatorFormats[name]; ok && t.Kind() == reflect.String {
+		schema["format"] = format
+		return
+	}
+	if pattern, ok := validatorPatterns[name]; ok && t.Kind() == reflect.String {
+		schema["pattern"] = pattern
+		return
+	}
+
+	switch name {
+	case "min", "max", "len", "gt", "gte", "lt", "lte":
+		if n, err := strconv.ParseFloat(param, 64); err == nil {
+			applyBound(schema, t, name, n)
+		}
+	case "oneof":
+		var enum []interface{}
+		for _, value := range oneofPattern.FindAllString(param, -1) {
+			enum = append(enum, tagValue(strings.Trim(value, "'"), t))
+		}
+		schema["enum"] = enum
+	case "unique":
+		if kind := t.Kind(); kind == reflect.Slice || kind == reflect.Array {
+			schema["uniqueItems"] = true
+		}
+	case "startswith", "endswith", "contains":
+		if _, ok := schema["pattern"]; ok || t.Kind() != reflect.String {
+			// a schema has a single pattern
+			return
+		}
+		pattern := regexp.QuoteMeta(param)
+		switch name {
+		case "startswith":
+			pattern = "^" + pattern
+		case "endswith":
+			pattern += "$"
+		}
+		schema["pattern"] = pattern
+	case "datetime":
+		switch param {
+		case time.RFC3339:
+			schema["format"] = "date-time"
+		case "2006-01-02":
+			schema["format"] = "date"
+		}
+	}
+}
+
+// applyBound maps a validator bound onto length, size or value keywords depending on the type,
+// following go-playground/validator: lengths for strings, sizes for slices and maps, values otherwise
+func applyBound(schema map[string]interface{}, t reflect.Type, rule string, n float64) {
+	var minKey, maxKey string
+	switch t.Kind() {
+	case reflect.String:
+		minKey, maxKey = "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		minKey, maxKey = "minItems", "maxItems"
+	case reflect.Map:
+		minKey, maxKey = "minProperties", "maxProperties"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		switch rule {
+		case "min", "gte":
+			schema["minimum"] = n
+		case "max", "lte":
+			schema["maximum"] = n
+		case "len":
+			schema["minimum"], schema["maximum"] = n, n
+		case "gt":
+			schema["minimum"], schema["exclusiveMinimum"] = n, true
+		case "lt":
+			schema["maximum"], schema["exclusiveMaximum"] = n, true
+		}
+		return
+	default:
+		// bounds of times and structs compare values a schema cannot express
+		return
+	}
+
+	count := int(n)
+	switch rule {
+	case "min", "gte":
+		schema[minKey] = count
+	case "max", "lte":
+		schema[maxKey] = count
+	case "len":
+		schema[minKey], schema[maxKey] = count, count
+	case "gt":
+		schema[minKey] = count + 1
+	case "lt":
+		schema[maxKey] = count - 1
+	}
+}
+
+// applySwaggerTag applies the options of a swagger:"..." tag and reports whether it marks the field required
+func applySwaggerTag(schema map[string]interface{}, t reflect.Type, tag string) (required bool) {
+	options := parseSwaggerTag(tag)
+	for _, key := range sortedStringKeys(options) {
+		value := options[key]
+		switch key {
+		case "description", "title", "format", "pattern":
+			schema[key] = value
+		case "example", "default":
+			schema[key] = tagValue(value, t)
+		case "enum":
+			var enum []interface{}
+			for _, item := range strings.Split(value, "|") {
+				enum = append(enum, tagValue(item, t))
+			}
+			schema["enum"] = enum
+		case "minimum", "maximum":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				schema[key] = n
+			}
+		case "minLength", "maxLength":
+			if n, err := strconv.Atoi(value); err == nil {
+				schema[key] = n
+			}
+		case "deprecated":
+			schema["x-deprecated"] = true
+		case "nullable":
+			schema["x-nullable"] = true
+		case "readOnly":
+			schema["readOnly"] = true
+		case "required":
+			required = true
+		}
+	}
+	return required
+}
+
+// parseSwaggerTag splits a swagger:"..." tag into its options. A comma only starts a new option
+// when a known option follows, so descriptions may contain commas.
+func parseSwaggerTag(tag string) map[string]string {
+	options := make(map[string]string)
+	last := ""
+	for _, part := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(part), "=")
+		if takesValue, known := swaggerTagOptions[key]; known && takesValue == hasValue {
+			options[key] = value
+			last = key
+			if !takesValue {
+				options[key] = "true"
+				last = ""
+			}
+			continue
+		}
+		if last != "" {
+			options[last] += "," + part
+		}
+	}
+	return options
+}
+
+// tagValue converts a tag value to the JSON type of t, keeping the string when it does not parse
+func tagValue(value string, t reflect.Type) interface{} {
+	t = indirectType(t)
+	if t == timeType {
+		return value
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		items := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, tagValue(strings.TrimSpace(item), t.Elem()))
+		}
+		return items
+	case reflect.Map, reflect.Struct, reflect.Interface:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
+}
+
+// indirectType follows pointers to the type they point to
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// hasJSONOption reports whether the options of a json tag include option
+func hasJSONOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedStringKeys returns the keys of m in order
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringsToInterfaces converts a string slice to the []interface{} decoded JSON uses
+func stringsToInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
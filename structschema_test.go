@@ -0,0 +1,191 @@
+package swagger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaAddress struct {
+	City    string `json:"city" binding:"required"`
+	Country string `json:"country" validate:"len=2,alpha" example:"DE"`
+}
+
+type schemaPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type schemaAudit struct {
+	CreatedAt time.Time `json:"createdAt" swagger:"readOnly"`
+}
+
+type schemaUser struct {
+	schemaAudit
+	ID       string            `json:"id" swagger:"format=uuid,readOnly,description=Server assigned, never reused"`
+	Email    string            `json:"email" binding:"required,email"`
+	Name     string            `json:"name" validate:"required,min=1,max=100" example:"Ada"`
+	Age      int               `json:"age,omitempty" validate:"gte=18,lt=130"`
+	Score    float64           `json:"score" binding:"gt=0,lte=1"`
+	Role     string            `json:"role" validate:"oneof=admin member 'read only'"`
+	Level    int32             `json:"level" binding:"oneof=1 2 3"`
+	Tags     []string          `json:"tags" validate:"max=5,unique,dive,min=2,startswith=#" example:"#go,#api"`
+	Labels   map[string]string `json:"labels" validate:"dive,max=10"`
+	Website  *string           `json:"website,omitempty" binding:"omitempty,url|uri"`
+	Count    int64             `json:"count,string"`
+	Status   string            `json:"status" swagger:"enum=active|blocked,default=active,deprecated"`
+	Home     schemaAddress     `json:"home" swagger:"description=Where the user lives"`
+	Work     *schemaAddress    `json:"work,omitempty" swagger:"nullable"`
+	Manager  *schemaUser       `json:"manager,omitempty"`
+	Avatar   []byte            `json:"avatar,omitempty"`
+	Password string            `json:"-"`
+	Internal string            `json:"internal" swagger:"-"`
+	secret   string
+}
+
+func TestSchemaOf(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}}, SchemaOf([]int{}))
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, SchemaOf(time.Time{}))
+
+	schema := SchemaOf(&schemaUser{})
+	props := schema["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"email", "name"}, schema["required"])
+	assert.NotContains(t, props, "Password")
+	assert.NotContains(t, props, "internal")
+	assert.NotContains(t, props, "secret")
+
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time", "readOnly": true}, props["createdAt"], "embedded fields are promoted")
+	assert.Equal(t, map[string]interface{}{
+		"type":        "string",
+		"format":      "uuid",
+		"readOnly":    true,
+		"description": "Server assigned, never reused",
+	}, props["id"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "email"}, props["email"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "minLength": 1, "maxLength": 100, "example": "Ada"}, props["name"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "minimum": float64(18), "maximum": float64(130), "exclusiveMaximum": true}, props["age"])
+	assert.Equal(t, map[string]interface{}{"type": "number", "minimum": float64(0), "exclusiveMinimum": true, "maximum": float64(1)}, props["score"])
+	assert.Equal(t, []interface{}{"admin", "member", "read only"}, props["role"].(map[string]interface{})["enum"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32", "enum": []interface{}{float64(1), float64(2), float64(3)}}, props["level"])
+	assert.Equal(t, map[string]interface{}{
+		"type":        "array",
+		"maxItems":    5,
+		"uniqueItems": true,
+		"items":       map[string]interface{}{"type": "string", "minLength": 2, "pattern": "^#"},
+		"example":     []interface{}{"#go", "#api"},
+	}, props["tags"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "maxLength": 10}, props["labels"].(map[string]interface{})["additionalProperties"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, props["website"], "alternatives are skipped")
+	assert.Equal(t, map[string]interface{}{"type": "string"}, props["count"])
+	assert.Equal(t, map[string]interface{}{
+		"type":         "string",
+		"enum":         []interface{}{"active", "blocked"},
+		"default":      "active",
+		"x-deprecated": true,
+	}, props["status"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "byte"}, props["avatar"])
+
+	home := props["home"].(map[string]interface{})
+	assert.Equal(t, "Where the user lives", home["description"])
+	assert.Equal(t, []interface{}{"city"}, home["required"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "minLength": 2, "maxLength": 2, "pattern": "^[a-zA-Z]+$", "example": "DE"},
+		home["properties"].(map[string]interface{})["country"])
+	assert.Equal(t, true, props["work"].(map[string]interface{})["x-nullable"])
+	assert.Equal(t, map[string]interface{}{"type": "object"}, props["manager"], "recursion stops without definitions")
+}
+
+func TestDefinitionsOf(t *testing.T) {
+	definitions := DefinitionsOf(schemaUser{})
+	assert.ElementsMatch(t, []string{"schemaUser", "schemaAddress"}, mapKeys(definitions))
+
+	props := definitions["schemaUser"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/schemaUser"}, props["manager"])
+	assert.Equal(t, map[string]interface{}{
+		"description": "Where the user lives",
+		"allOf":       []interface{}{map[string]interface{}{"$ref": "#/definitions/schemaAddress"}},
+	}, props["home"], "keywords next to $ref go through allOf")
+
+	swagger := New(quietConfig())
+	swagger.SetDefinitions(definitions)
+	swagger.SetPaths(map[string]interface{}{})
+	assert.NoError(t, ValidateSpec(swagger.GetSpec()), "generated definitions are valid Swagger 2.0")
+}
+
+func TestDefinitionsOfNames(t *testing.T) {
+	type Location struct {
+		City string `json:"city"`
+	}
+	type schemaPlaces struct {
+		Home     Location                     `json:"home"`
+		Zone     time.Location                `json:"zone"`
+		Users    schemaPage[schemaAddress]    `json:"users"`
+		Counts   schemaPage[map[string]int64] `json:"counts"`
+		Previous *schemaPage[schemaAddress]   `json:"previous"`
+		Shadow   struct{ Location *Location } `json:"shadow"`
+	}
+	definitions := DefinitionsOf(schemaPlaces{})
+	assert.ElementsMatch(t, []string{
+		"schemaPlaces", "Location", "TimeLocation", "schemaAddress", "schemaPageSchemaAddress", "schemaPageMapStringInt64",
+	}, mapKeys(definitions))
+
+	props := definitions["schemaPlaces"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/TimeLocation"}, props["zone"], "same name in another package")
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/schemaPageSchemaAddress"}, props["users"])
+	assert.Equal(t, props["users"], props["previous"])
+
+	swagger := New(quietConfig())
+	swagger.SetDefinitions(definitions)
+	swagger.SetPaths(map[string]interface{}{})
+	assert.NoError(t, ValidateSpec(swagger.GetSpec()), "every $ref resolves")
+
+	type schemaAddress struct {
+		Street string `json:"street"`
+	}
+	definitions = DefinitionsOf(struct {
+		Outer  schemaPlaces
+		Inner  schemaAddress
+		Nested schemaPage[schemaAddress]
+	}{})
+	assert.Contains(t, definitions, "schemaAddress2", "same name in the same package")
+	assert.Contains(t, definitions, "schemaPageSchemaAddress2")
+}
+
+// TestSchemaOfMatchesBinding checks that values gin's binding rejects are rejected by the schema too
+func TestSchemaOfMatchesBinding(t *testing.T) {
+	type signup struct {
+		Email string   `json:"email" binding:"required,email"`
+		Name  string   `json:"name" binding:"required,min=2,max=5"`
+		Age   int      `json:"age" binding:"gte=18,lt=130"`
+		Plan  string   `json:"plan" binding:"oneof=free pro"`
+		Tags  []string `json:"tags" binding:"max=2,dive,alphanum"`
+	}
+	schema := SchemaOf(signup{})
+
+	tests := map[string]signup{
+		"valid":           {Email: "ada@example.com", Name: "Ada", Age: 36, Plan: "pro", Tags: []string{"go"}},
+		"bad email":       {Email: "ada", Name: "Ada", Age: 36, Plan: "pro"},
+		"short name":      {Email: "ada@example.com", Name: "A", Age: 36, Plan: "pro"},
+		"long name":       {Email: "ada@example.com", Name: "Ada Lovelace", Age: 36, Plan: "pro"},
+		"too young":       {Email: "ada@example.com", Name: "Ada", Age: 17, Plan: "pro"},
+		"too old":         {Email: "ada@example.com", Name: "Ada", Age: 130, Plan: "pro"},
+		"unknown plan":    {Email: "ada@example.com", Name: "Ada", Age: 36, Plan: "team"},
+		"too many tags":   {Email: "ada@example.com", Name: "Ada", Age: 36, Plan: "pro", Tags: []string{"a", "b", "c"}},
+		"tag with spaces": {Email: "ada@example.com", Name: "Ada", Age: 36, Plan: "pro", Tags: []string{"a b"}},
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(value)
+			require.NoError(t, err)
+			var decoded interface{}
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			bindingErr := binding.Validator.ValidateStruct(value)
+			schemaErrs := validateValue(nil, schema, decoded, "")
+			assert.Equal(t, bindingErr == nil, len(schemaErrs) == 0, "binding: %v, schema: %v", bindingErr, schemaErrs)
+		})
+	}
+}
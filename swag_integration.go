@@ -2,11 +2,11 @@ package swagger
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // SwagSpec holds the global swagger spec parsed from swag init
@@ -33,52 +33,18 @@ var SwagSpec interface{}
 //   - router: Gin engine instance
 //   - swagSpec: Parsed swagger spec from LoadSwagDocs()
 //   - config: Configuration options (use DefaultConfig() for defaults)
-func SetupWithSwag(router *gin.Engine, swagSpec interface{}, config *Config) {
-	if config == nil {
-		config = DefaultConfig()
+//
+// When config.FailOnInvalidSpec is set, the spec is checked with ValidateSpec
+// and no routes are registered if it is invalid.
+func SetupWithSwag(router *gin.Engine, swagSpec interface{}, config *Config) error {
+	handler, err := NewHandler(swagSpec, config)
+	if err != nil {
+		return err
 	}
-
-	// Skip if disabled
-	if !config.Enabled {
-		return
+	if handler.Config().Enabled {
+		SwagSpec = swagSpec
 	}
-
-	SwagSpec = swagSpec
-
-	// Serve dynamic swagger.json with auto-detected host
-	router.GET(config.JSONPath, func(c *gin.Context) {
-		// Parse the swag-generated spec
-		specMap, ok := swagSpec.(map[string]interface{})
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid swagger spec"})
-			return
-		}
-
-		// Clone the spec to avoid modifying the original
-		dynamicSpec := make(map[string]interface{})
-		for k, v := range specMap {
-			dynamicSpec[k] = v
-		}
-
-		// Override host and schemes with auto-detection if enabled
-		if config.AutoDetectHost {
-			dynamicSpec["host"] = detectHost(c)
-			dynamicSpec["schemes"] = []string{detectScheme(c)}
-		} else if config.Host != "" {
-			dynamicSpec["host"] = config.Host
-			if len(config.Schemes) > 0 {
-				dynamicSpec["schemes"] = config.Schemes
-			} else {
-				dynamicSpec["schemes"] = []string{detectScheme(c)}
-			}
-		}
-
-		c.JSON(http.StatusOK, dynamicSpec)
-	})
-
-	// Serve Swagger UI
-	url := ginSwagger.URL(config.JSONPath)
-	router.GET(config.UIPath+"/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+	return SetupHandler(router, handler)
 }
 
 // LoadSwagDocs parses swag-generated documentation into a swagger spec.
@@ -106,6 +72,31 @@ func LoadSwagDocs(docJSON string) (interface{}, error) {
 	return spec, nil
 }
 
+// LoadSpecFile reads a swagger.json or swagger.yaml file (e.g., docs/swagger.yaml from swag init)
+// into a swagger spec. YAML is detected by the .yaml or .yml extension.
+//
+// Example:
+//
+//	swagSpec, err := swagger.LoadSpecFile("docs/swagger.yaml")
+//
+// Returns:
+//   - interface{}: Parsed swagger spec (map[string]interface{})
+//   - error: Read or parse error
+func LoadSpecFile(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := decodeDocument(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := spec.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%s: swagger spec must be an object", path)
+	}
+	return spec, nil
+}
+
 // SetupFromDocs is a convenience function that loads swag-generated docs and sets up Swagger UI.
 // This expects that docs are imported with: import _ "yourapp/docs"
 //
@@ -144,6 +135,5 @@ func SetupFromDocs(router *gin.Engine, swaggerInfo interface{}, config *Config)
 	}
 
 	// Setup with loaded spec
-	SetupWithSwag(router, swagSpec, config)
-	return nil
+	return SetupWithSwag(router, swagSpec, config)
 }
@@ -1,29 +1,29 @@
 package swagger
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // Swagger manages the Swagger documentation
 type Swagger struct {
 	config *Config
 	spec   *SwaggerSpec
+	// version counts SetPaths and SetDefinitions calls
+	version atomic.Int64
 }
 
 // SwaggerSpec represents the OpenAPI/Swagger specification
 type SwaggerSpec struct {
 	Swagger             string                        `json:"swagger"`
 	Info                Info                          `json:"info"`
-	Host                string                        `json:"host"`
-	BasePath            string                        `json:"basePath"`
-	Schemes             []string                      `json:"schemes"`
-	Paths               map[string]interface{}        `json:"paths,omitempty"`
+	Host                string                        `json:"host,omitempty"`
+	BasePath            string                        `json:"basePath,omitempty"`
+	Schemes             []string                      `json:"schemes,omitempty"`
+	Paths               map[string]interface{}        `json:"paths"`
 	Definitions         map[string]interface{}        `json:"definitions,omitempty"`
 	SecurityDefinitions map[string]SecurityDefinition `json:"securityDefinitions,omitempty"`
 }
@@ -111,68 +111,124 @@ func New(config *Config) *Swagger {
 	}
 }
 
-// Setup configures Swagger UI routes on a Gin router
-func Setup(router *gin.Engine, config *Config) {
-	swagger := New(config)
-
-	// Skip if disabled
-	if !config.Enabled {
-		return
-	}
-
-	// Serve dynamic swagger.json
-	router.GET(config.JSONPath, swagger.docHandler)
-
-	// Serve Swagger UI
-	url := ginSwagger.URL(config.JSONPath)
-	router.GET(config.UIPath+"/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+// Setup configures Swagger UI routes on a Gin router.
+// The config is validated first (see Config.Validate); invalid configs and routes
+// that conflict with existing ones are returned as errors and nothing is served.
+func Setup(router *gin.Engine, config *Config) error {
+	_, err := SetupWithInstance(router, config)
+	return err
 }
 
 // SetupWithInstance configures Swagger UI routes and returns the Swagger instance for manual configuration
-func SetupWithInstance(router *gin.Engine, config *Config) *Swagger {
+func SetupWithInstance(router *gin.Engine, config *Config) (*Swagger, error) {
+	config, err := prepareConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	swagger := New(config)
 
-	// Skip if disabled
-	if !config.Enabled {
-		return swagger
+	handler, err := NewHandler(swagger, config)
+	if err != nil {
+		return nil, err
 	}
+	if err := SetupHandler(router, handler); err != nil {
+		return nil, err
+	}
+	return swagger, nil
+}
 
-	// Serve dynamic swagger.json
-	router.GET(config.JSONPath, swagger.docHandler)
+// prepareConfig falls back to DefaultConfig and validates the config
+func prepareConfig(config *Config) (*Config, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid swagger config: %w", err)
+	}
+	config.applyProfile()
+	return config, nil
+}
 
-	// Serve Swagger UI
-	url := ginSwagger.URL(config.JSONPath)
-	router.GET(config.UIPath+"/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+// startupSummary describes the served routes and how the host is determined
+func startupSummary(config *Config, events bool) string {
+	parts := []string{
+		"UI " + config.UIPath + "/index.html",
+		"spec " + config.JSONPath,
+	}
+	if config.PostmanPath != "" {
+		parts = append(parts, "postman "+config.PostmanPath)
+	}
+	if config.InsomniaPath != "" {
+		parts = append(parts, "insomnia "+config.InsomniaPath)
+	}
+	if events {
+		parts = append(parts, "live reload "+config.UIPath+"/events")
+	}
 
-	return swagger
+	profile := config.ActiveProfile()
+	switch {
+	case profile != nil && len(profile.Servers) > 0:
+		parts = append(parts, "servers "+strings.Join(profile.Servers, ", "))
+	case config.AutoDetectHost:
+		parts = append(parts, "host auto-detected from requests")
+	case config.Host != "" && len(config.Schemes) > 0:
+		parts = append(parts, fmt.Sprintf("host %s (%s)", config.Host, strings.Join(config.Schemes, ", ")))
+	case config.Host != "":
+		parts = append(parts, fmt.Sprintf("host %s (scheme detected from requests)", config.Host))
+	default:
+		parts = append(parts, "host from spec")
+	}
+	if profile != nil {
+		parts = append(parts, "environment "+profile.Name)
+		if len(profile.Auth) > 0 {
+			parts = append(parts, "basic auth")
+		}
+		if profile.Mock {
+			parts = append(parts, "mock mode")
+		}
+	}
+	return "[SWAGGER] " + strings.Join(parts, " | ")
 }
 
-// docHandler serves the swagger.json with dynamic host and scheme
-func (s *Swagger) docHandler(c *gin.Context) {
-	// Update host if auto-detection is enabled
-	if s.config.AutoDetectHost {
-		s.spec.Host = detectHost(c)
-		s.spec.Schemes = []string{detectScheme(c)}
-	} else if s.config.Host != "" {
-		s.spec.Host = s.config.Host
-		if len(s.config.Schemes) > 0 {
-			s.spec.Schemes = s.config.Schemes
-		} else {
-			s.spec.Schemes = []string{detectScheme(c)}
+// decorateSpec merges recorded examples, adds code samples when the config enables them
+// and applies the active profile
+func decorateSpec(spec interface{}, config *Config) (interface{}, error) {
+	if config.Recorder != nil {
+		merged, err := MergeExamples(spec, config.Recorder.Examples())
+		if err != nil {
+			return nil, err
 		}
+		spec = merged
 	}
-
-	c.JSON(http.StatusOK, s.spec)
+	if config.CodeSamples {
+		withSamples, err := AddCodeSamples(spec)
+		if err != nil {
+			return nil, err
+		}
+		spec = withSamples
+	}
+	if profile := config.ActiveProfile(); profile != nil {
+		specMap, err := toSpecMap(spec)
+		if err != nil {
+			return nil, err
+		}
+		specMap = shallowCopy(specMap)
+		decorateForProfile(specMap, profile)
+		spec = specMap
+	}
+	return spec, nil
 }
 
 // SetPaths sets the API paths (from swag generated docs)
 func (s *Swagger) SetPaths(paths map[string]interface{}) {
 	s.spec.Paths = paths
+	s.version.Add(1)
 }
 
 // SetDefinitions sets the API definitions (from swag generated docs)
 func (s *Swagger) SetDefinitions(definitions map[string]interface{}) {
 	s.spec.Definitions = definitions
+	s.version.Add(1)
 }
 
 // GetSpec returns the current Swagger specification
@@ -182,9 +238,12 @@ func (s *Swagger) GetSpec() *SwaggerSpec {
 
 // ExportJSON exports the Swagger spec as JSON string
 func (s *Swagger) ExportJSON() (string, error) {
-	data, err := json.MarshalIndent(s.spec, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal swagger spec: %w", err)
-	}
-	return string(data), nil
+	data, err := MarshalSpec(s.spec, "json")
+	return string(data), err
+}
+
+// ExportYAML exports the Swagger spec as YAML string
+func (s *Swagger) ExportYAML() (string, error) {
+	data, err := MarshalSpec(s.spec, "yaml")
+	return string(data), err
 }
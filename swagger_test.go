@@ -1,14 +1,32 @@
 package swagger
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// TestMain runs the tests without the SWAGGER_* variables of the calling shell, which would select
+// a profile or override the config under test; tests that need one set it with t.Setenv
+func TestMain(m *testing.M) {
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "SWAGGER_") {
+			os.Unsetenv(name)
+		}
+	}
+	os.Exit(m.Run())
+}
+
 func TestNew(t *testing.T) {
 	t.Run("with custom config", func(t *testing.T) {
 		config := NewConfig().
@@ -103,6 +121,25 @@ func TestExportJSON(t *testing.T) {
 	assert.Contains(t, json, "api.example.com")
 }
 
+func TestMarshalSpec(t *testing.T) {
+	s := New(NewConfig().WithTitle("Test API"))
+	exported, err := s.ExportYAML()
+	require.NoError(t, err)
+
+	for _, spec := range []interface{}{s, s.GetSpec()} {
+		data, err := MarshalSpec(spec, "yaml")
+		require.NoError(t, err)
+		assert.Equal(t, exported, string(data))
+	}
+
+	data, err := MarshalSpec(`{"swagger": "2.0", "info": {"title": "Raw"}}`, "json")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"info\": {\n    \"title\": \"Raw\"\n  },\n  \"swagger\": \"2.0\"\n}", string(data))
+
+	_, err = MarshalSpec(s, "xml")
+	assert.EqualError(t, err, `unknown spec format "xml", expected json or yaml`)
+}
+
 func TestDetectHost(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -114,7 +151,7 @@ func TestDetectHost(t *testing.T) {
 			},
 		}
 
-		host := detectHost(c)
+		host := detectHost(c.Request)
 		assert.Equal(t, "api.railway.app", host)
 	})
 
@@ -125,7 +162,7 @@ func TestDetectHost(t *testing.T) {
 			Header: http.Header{},
 		}
 
-		host := detectHost(c)
+		host := detectHost(c.Request)
 		assert.Equal(t, "api.example.com", host)
 	})
 
@@ -136,7 +173,7 @@ func TestDetectHost(t *testing.T) {
 			Header: http.Header{},
 		}
 
-		host := detectHost(c)
+		host := detectHost(c.Request)
 		assert.Equal(t, "api.example.com", host)
 	})
 
@@ -146,7 +183,7 @@ func TestDetectHost(t *testing.T) {
 			Header: http.Header{},
 		}
 
-		host := detectHost(c)
+		host := detectHost(c.Request)
 		assert.Equal(t, "localhost:8080", host)
 	})
 }
@@ -162,7 +199,7 @@ func TestDetectScheme(t *testing.T) {
 			},
 		}
 
-		scheme := detectScheme(c)
+		scheme := detectScheme(c.Request, "http")
 		assert.Equal(t, "https", scheme)
 	})
 
@@ -173,7 +210,7 @@ func TestDetectScheme(t *testing.T) {
 			Header: http.Header{},
 		}
 
-		scheme := detectScheme(c)
+		scheme := detectScheme(c.Request, "http")
 		assert.Equal(t, "https", scheme)
 	})
 
@@ -184,7 +221,7 @@ func TestDetectScheme(t *testing.T) {
 			Header: http.Header{},
 		}
 
-		scheme := detectScheme(c)
+		scheme := detectScheme(c.Request, "http")
 		assert.Equal(t, "http", scheme)
 	})
 }
@@ -196,6 +233,92 @@ func TestIsLocalhost(t *testing.T) {
 	assert.False(t, isLocalhost("api.railway.app"))
 }
 
+func TestConfigValidate(t *testing.T) {
+	t.Run("normalises defaults", func(t *testing.T) {
+		config := &Config{UIPath: "/docs/"}
+		require.NoError(t, config.Validate())
+		assert.Equal(t, "API", config.Title)
+		assert.Equal(t, "1.0.0", config.Version)
+		assert.Equal(t, "/docs", config.UIPath)
+		assert.Equal(t, "/swagger.json", config.JSONPath)
+	})
+
+	t.Run("returns every problem", func(t *testing.T) {
+		config := DefaultConfig().WithUIPath("/").WithSchemes([]string{"ws", "https"})
+		config.JSONPath = "swagger.json"
+
+		err := config.Validate()
+		var problems ConfigErrors
+		require.True(t, errors.As(err, &problems))
+		assert.Equal(t, ConfigErrors{
+			{Field: "jsonPath", Message: `must start with "/", got "swagger.json"`},
+			{Field: "uiPath", Message: `must not be "/", the UI routes under it would shadow every other route`},
+			{Field: "schemes", Message: `must be http or https, got "ws"`},
+		}, problems)
+	})
+
+	t.Run("default config matches new config", func(t *testing.T) {
+		assert.Equal(t, NewConfig(), DefaultConfig())
+	})
+}
+
+func TestSetupErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("spec under the UI path", func(t *testing.T) {
+		router := gin.New()
+		err := Setup(router, DefaultConfig().WithJSONPath("/swagger/doc.json"))
+		assert.EqualError(t, err, `invalid swagger config: jsonPath: "/swagger/doc.json" collides with the UI routes under uiPath "/swagger"`)
+		assert.Empty(t, router.Routes(), "nothing is registered")
+	})
+
+	t.Run("conflict with an existing route", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/swagger/*path", func(c *gin.Context) {})
+		err := Setup(router, DefaultConfig().WithLogger(log.New(&bytes.Buffer{}, "", 0)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to register swagger routes")
+		require.Len(t, router.Routes(), 1, "nothing is registered")
+
+		router = gin.New()
+		router.HEAD("/swagger.json", func(c *gin.Context) {})
+		require.Error(t, Setup(router, quietConfig()), "HEAD routes are checked too")
+		require.Len(t, router.Routes(), 1, "nothing is registered")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("swag setup", func(t *testing.T) {
+		err := SetupWithSwag(gin.New(), map[string]interface{}{"swagger": "2.0"}, DefaultConfig().WithUIPath("docs"))
+		assert.EqualError(t, err, `invalid swagger config: uiPath: must start with "/", got "docs"`)
+	})
+}
+
+func TestSetupLogsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	router := gin.New()
+
+	config := DefaultConfig().WithPostmanPath("/postman.json").WithLogger(log.New(&logs, "", 0))
+	require.NoError(t, Setup(router, config))
+	assert.Equal(t, "[SWAGGER] UI /swagger/index.html | spec /swagger.json | postman /postman.json | host auto-detected from requests\n", logs.String())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	for _, path := range []string{"/swagger.json", "/swagger/index.html"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, "HEAD "+path)
+	}
+
+	fixed := DefaultConfig().WithAutoDetectHost(false).WithHost("api.example.com").WithSchemes([]string{"https"})
+	assert.Equal(t, "[SWAGGER] UI /swagger/index.html | spec /swagger.json | host api.example.com (https)", startupSummary(fixed, false))
+	assert.Contains(t, startupSummary(DefaultConfig().WithAutoDetectHost(false), false), "host from spec")
+}
+
 func ExampleSetup() {
 	router := gin.New()
 
@@ -206,7 +329,10 @@ func ExampleSetup() {
 		WithAutoDetectHost(true).
 		WithBearerAuth(true)
 
-	Setup(router, config)
+	if err := Setup(router, config); err != nil {
+		fmt.Println(err)
+		return
+	}
 
 	fmt.Println("Swagger UI available at /swagger/index.html")
 	// Output: Swagger UI available at /swagger/index.html
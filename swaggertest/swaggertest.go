@@ -0,0 +1,164 @@
+// Package swaggertest checks that a router honours its Swagger spec.
+//
+// Run builds a request for every documented operation from examples and generated data,
+// serves it through the router with httptest and asserts that the response status is
+// documented and the body matches the response schema:
+//
+//	func TestContract(t *testing.T) {
+//	    router := setupRouter()
+//	    swaggertest.Run(t, router, docs.SwaggerInfo.ReadDoc(), swaggertest.Options{
+//	        Prepare: func(op swagger.ExampleRequest, r *http.Request) {
+//	            r.Header.Set("Authorization", "Bearer "+testToken)
+//	        },
+//	    })
+//	}
+package swaggertest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+)
+
+// Options configures a contract test run
+type Options struct {
+	// Seed selects the generated data (default: 0)
+	Seed int64
+
+	// Prepare is called before each request is served, e.g. to add authentication or seed fixtures
+	Prepare func(op swagger.ExampleRequest, r *http.Request)
+
+	// Skip lists operations to leave out, by operationId or "METHOD /path"
+	Skip []string
+}
+
+// Result is the outcome of one operation
+type Result struct {
+	// Operation identifies the operation as "METHOD /path"
+	Operation string
+
+	// Status is the response status, 0 when skipped
+	Status int
+
+	// Skipped is true for operations listed in Options.Skip
+	Skipped bool
+
+	// Violations are the contract violations of the response
+	Violations []swagger.Violation
+}
+
+// Passed reports whether the operation was exercised without violations
+func (r Result) Passed() bool {
+	return !r.Skipped && len(r.Violations) == 0
+}
+
+// Report summarises a contract test run
+type Report struct {
+	Results []Result
+}
+
+// Exercised returns the number of operations that were called
+func (r *Report) Exercised() int {
+	count := 0
+	for _, result := range r.Results {
+		if !result.Skipped {
+			count++
+		}
+	}
+	return count
+}
+
+// Passed returns the number of operations without violations
+func (r *Report) Passed() int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Passed() {
+			count++
+		}
+	}
+	return count
+}
+
+// Coverage returns the fraction of documented operations that were exercised
+func (r *Report) Coverage() float64 {
+	if len(r.Results) == 0 {
+		return 0
+	}
+	return float64(r.Exercised()) / float64(len(r.Results))
+}
+
+// String formats the coverage summary
+func (r *Report) String() string {
+	return fmt.Sprintf("%d/%d operations exercised (%.0f%%), %d passed",
+		r.Exercised(), len(r.Results), r.Coverage()*100, r.Passed())
+}
+
+// Run exercises every operation of a Swagger 2.0 spec (*swagger.Swagger, *swagger.SwaggerSpec,
+// swag spec or raw JSON) against a router, usually a *gin.Engine. Each operation runs as a
+// subtest named after its operationId (see subtestName); the coverage summary is logged and returned.
+func Run(t *testing.T, router http.Handler, spec interface{}, options ...Options) *Report {
+	t.Helper()
+	var opts Options
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	requests, err := swagger.ExampleRequests(spec, opts.Seed)
+	if err != nil {
+		t.Fatalf("swaggertest: %v", err)
+	}
+	validator, err := swagger.NewResponseValidator(spec, swagger.ResponseValidationOptions{})
+	if err != nil {
+		t.Fatalf("swaggertest: %v", err)
+	}
+
+	skip := make(map[string]bool, len(opts.Skip))
+	for _, name := range opts.Skip {
+		skip[name] = true
+	}
+
+	report := &Report{}
+	for _, op := range requests {
+		op := op
+		result := Result{Operation: op.Name()}
+		t.Run(subtestName(op), func(t *testing.T) {
+			if skip[op.Name()] || (op.OperationID != "" && skip[op.OperationID]) {
+				result.Skipped = true
+				t.Skip("skipped by options")
+			}
+			if opts.Prepare != nil {
+				opts.Prepare(op, op.Request)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, op.Request)
+
+			result.Status = w.Code
+			result.Violations = validator.ValidateResponse(op.Method, op.Path, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+			for _, violation := range result.Violations {
+				if violation.Pointer != "" {
+					t.Errorf("%s %s: response body %s: %s", op.Method, op.Request.URL, violation.Pointer, violation.Message)
+				} else {
+					t.Errorf("%s %s: %s", op.Method, op.Request.URL, violation.Message)
+				}
+			}
+		})
+		report.Results = append(report.Results, result)
+	}
+
+	t.Logf("swaggertest: %s", report)
+	return report
+}
+
+// subtestName names the subtest of an operation: its operationId, else "METHOD path" with the
+// slashes of the path replaced, since go test treats them as subtest separators
+func subtestName(op swagger.ExampleRequest) string {
+	if op.OperationID != "" {
+		return op.OperationID
+	}
+	return op.Method + " " + strings.ReplaceAll(strings.TrimPrefix(op.Path, "/"), "/", "_")
+}
@@ -0,0 +1,116 @@
+package swaggertest
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	swagger "github.com/OkanUysal/go-swagger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "version": "1.0"},
+	"basePath": "/api",
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"parameters": [{"name": "limit", "in": "query", "type": "integer", "required": true, "minimum": 1, "maximum": 10}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"parameters": [{"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}],
+				"responses": {"201": {"description": "Created", "schema": {"$ref": "#/definitions/Pet"}}}
+			}
+		},
+		"/pets/{id}": {
+			"get": {
+				"operationId": "getPet",
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer", "x-example": 7}],
+				"responses": {
+					"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}},
+					"404": {"description": "Not found"}
+				}
+			},
+			"delete": {
+				"operationId": "deletePet",
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+				"responses": {"204": {"description": "Deleted"}}
+			}
+		}
+	},
+	"definitions": {
+		"Pet": {
+			"type": "object",
+			"required": ["id", "name"],
+			"properties": {
+				"id": {"type": "integer"},
+				"name": {"type": "string"}
+			}
+		}
+	}
+}`
+
+func newPetRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api")
+	api.GET("/pets", func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.Query("limit"))
+		require.NoError(t, err)
+		pets := make([]gin.H, limit)
+		for i := range pets {
+			pets[i] = gin.H{"id": i + 1, "name": "Rex"}
+		}
+		c.JSON(http.StatusOK, pets)
+	})
+	api.POST("/pets", func(c *gin.Context) {
+		var pet map[string]interface{}
+		require.NoError(t, c.ShouldBindJSON(&pet))
+		c.JSON(http.StatusCreated, pet)
+	})
+	api.GET("/pets/:id", func(c *gin.Context) {
+		if c.Param("id") != "7" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": 7, "name": "Rex"})
+	})
+	return router
+}
+
+func TestRun(t *testing.T) {
+	var prepared []string
+	report := Run(t, newPetRouter(t), petSpec, Options{
+		Seed: 3,
+		Skip: []string{"deletePet"},
+		Prepare: func(op swagger.ExampleRequest, r *http.Request) {
+			prepared = append(prepared, op.OperationID)
+		},
+	})
+
+	assert.Equal(t, []string{"listPets", "createPet", "getPet"}, prepared)
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, 3, report.Exercised())
+	assert.Equal(t, 3, report.Passed())
+	assert.Equal(t, 0.75, report.Coverage())
+	assert.Equal(t, "3/4 operations exercised (75%), 3 passed", report.String())
+
+	for _, result := range report.Results {
+		if result.Operation == "GET /pets/{id}" {
+			assert.Equal(t, http.StatusOK, result.Status, "x-example is used for the path parameter")
+		}
+	}
+}
+
+func TestSubtestName(t *testing.T) {
+	assert.Equal(t, "getPet", subtestName(swagger.ExampleRequest{OperationID: "getPet", Method: "GET", Path: "/pets/{id}"}))
+	assert.Equal(t, "GET pets_{id}_photos", subtestName(swagger.ExampleRequest{Method: "GET", Path: "/pets/{id}/photos"}),
+		"slashes would nest subtests")
+}
@@ -0,0 +1,58 @@
+package swagger
+
+import "html/template"
+
+// uiIndexTemplate is the Swagger UI page. Unlike the gin-swagger page it lets the
+// active profile switch "Try it out" off and fetches the spec again on reload events.
+var uiIndexTemplate = template.Must(template.New("index.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" type="text/css" href="./swagger-ui.css">
+  <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32">
+  <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16">
+  <style>
+    html { box-sizing: border-box; overflow-y: scroll; }
+    *, *:before, *:after { box-sizing: inherit; }
+    body { margin: 0; background: #fafafa; }
+  </style>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="./swagger-ui-bundle.js"></script>
+<script src="./swagger-ui-standalone-preset.js"></script>
+<script>
+window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: {{.URL}},
+    dom_id: "#swagger-ui",
+    validatorUrl: null,
+    deepLinking: true,
+    docExpansion: "list",
+    defaultModelsExpandDepth: 1,
+{{- if not .TryItOut}}
+    supportedSubmitMethods: [],
+{{- end}}
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+{{- if .Events}}
+  new EventSource({{.Events}}).addEventListener("reload", function() {
+    window.ui.specActions.download();
+  });
+{{- end}}
+};
+</script>
+</body>
+</html>
+`))
+
+// uiPage holds the values rendered into uiIndexTemplate
+type uiPage struct {
+	Title    string
+	URL      string
+	TryItOut bool
+	Events   string
+}
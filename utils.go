@@ -1,21 +1,24 @@
 package swagger
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
-	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
 )
 
 // detectHost automatically detects the host from the request or environment
-func detectHost(c *gin.Context) string {
+func detectHost(r *http.Request) string {
 	// 1. Check X-Forwarded-Host header (reverse proxy, Railway, Nginx)
-	if host := c.Request.Header.Get("X-Forwarded-Host"); host != "" {
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
 		return host
 	}
 
 	// 2. Check Host header
-	if host := c.Request.Host; host != "" {
+	if host := r.Host; host != "" {
 		// Remove port for cleaner display if it's standard port
 		if strings.HasSuffix(host, ":80") || strings.HasSuffix(host, ":443") {
 			return strings.Split(host, ":")[0]
@@ -46,31 +49,27 @@ func detectHost(c *gin.Context) string {
 	return "localhost:8080"
 }
 
-// detectScheme automatically detects the scheme (http/https) from the request
-func detectScheme(c *gin.Context) string {
+// detectScheme automatically detects the scheme (http/https) from the request,
+// falling back to the given scheme (see Profile.DefaultScheme)
+func detectScheme(r *http.Request, fallback string) string {
 	// 1. Check if TLS is enabled
-	if c.Request.TLS != nil {
+	if r.TLS != nil {
 		return "https"
 	}
 
 	// 2. Check X-Forwarded-Proto header (reverse proxy)
-	if proto := c.Request.Header.Get("X-Forwarded-Proto"); proto != "" {
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
 		return proto
 	}
 
 	// 3. Check if host contains Railway domain (usually https)
-	host := c.Request.Host
+	host := r.Host
 	if strings.Contains(host, ".railway.app") || strings.Contains(host, ".up.railway.app") {
 		return "https"
 	}
 
-	// 4. Check environment
-	if os.Getenv("ENV") == "production" || os.Getenv("ENV") == "staging" {
-		return "https"
-	}
-
-	// 5. Default to http
-	return "http"
+	// 4. Default of the active profile
+	return fallback
 }
 
 // getEnvWithDefault gets an environment variable with a default value
@@ -81,13 +80,125 @@ func getEnvWithDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
-// isProduction checks if the environment is production
-func isProduction() bool {
-	env := strings.ToLower(os.Getenv("ENV"))
-	return env == "production" || env == "prod"
-}
-
 // isLocalhost checks if the host is localhost
 func isLocalhost(host string) bool {
 	return strings.Contains(host, "localhost") || strings.Contains(host, "127.0.0.1")
 }
+
+// toSpecMap converts a *SwaggerSpec, a swag spec from LoadSwagDocs or raw JSON
+// into a generic map. Maps are returned as-is; use cloneValue before mutating.
+func toSpecMap(spec interface{}) (map[string]interface{}, error) {
+	switch s := spec.(type) {
+	case nil:
+		return nil, fmt.Errorf("swagger spec is nil")
+	case map[string]interface{}:
+		return s, nil
+	case string:
+		return decodeSpecMap([]byte(s))
+	case []byte:
+		return decodeSpecMap(s)
+	case *Swagger:
+		return toSpecMap(s.spec)
+	default:
+		data, err := json.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal swagger spec: %w", err)
+		}
+		return decodeSpecMap(data)
+	}
+}
+
+// decodeSpecMap unmarshals a JSON document that must be an object
+func decodeSpecMap(data []byte) (map[string]interface{}, error) {
+	var specMap map[string]interface{}
+	if err := json.Unmarshal(data, &specMap); err != nil {
+		return nil, fmt.Errorf("invalid swagger spec: %w", err)
+	}
+	return specMap, nil
+}
+
+// cloneValue deep copies a JSON-like value made of maps, slices and scalars
+func cloneValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = cloneValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = cloneValue(val)
+		}
+		return out
+	case []string:
+		return append([]string(nil), t...)
+	default:
+		return v
+	}
+}
+
+// applyDetectedHost overrides host and schemes of a served spec map according to config.
+// Servers of the active profile take precedence over detection.
+func applyDetectedHost(spec map[string]interface{}, r *http.Request, config *Config) {
+	if profile := config.ActiveProfile(); profile != nil && len(profile.Servers) > 0 {
+		applyServers(spec, profile.Servers)
+		return
+	}
+	if config.AutoDetectHost {
+		spec["host"] = detectHost(r)
+		spec["schemes"] = []string{detectScheme(r, config.defaultScheme())}
+	} else if config.Host != "" {
+		spec["host"] = config.Host
+		if len(config.Schemes) > 0 {
+			spec["schemes"] = config.Schemes
+		} else {
+			spec["schemes"] = []string{detectScheme(r, config.defaultScheme())}
+		}
+	}
+}
+
+// marshalYAML renders a spec as YAML, going through JSON so field names and omitempty match the JSON output
+func marshalYAML(spec interface{}) (string, error) {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(specMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal swagger spec: %w", err)
+	}
+	return string(data), nil
+}
+
+// MarshalSpec renders a spec (anything SetupWithSwag accepts, or a *Swagger) as indented JSON
+// or as YAML, the output of ExportJSON and ExportYAML. format is "json" or "yaml".
+func MarshalSpec(spec interface{}, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		switch s := spec.(type) {
+		case *Swagger:
+			spec = s.spec
+		case string, []byte:
+			specMap, err := toSpecMap(s)
+			if err != nil {
+				return nil, err
+			}
+			spec = specMap
+		}
+		data, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal swagger spec: %w", err)
+		}
+		return data, nil
+	case "yaml":
+		data, err := marshalYAML(spec)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(data), nil
+	default:
+		return nil, fmt.Errorf("unknown spec format %q, expected json or yaml", format)
+	}
+}
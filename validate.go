@@ -0,0 +1,234 @@
+package swagger
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed schemas/*.json
+var metaSchemaFiles embed.FS
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     map[string]interface{}
+	metaSchemasErr  error
+
+	// pathParamPattern matches "{name}" segments of a path template
+	pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+)
+
+// loadMetaSchemas parses the embedded Swagger 2.0, OpenAPI 3.0 and draft-04 meta-schemas
+func loadMetaSchemas() (map[string]interface{}, error) {
+	metaSchemasOnce.Do(func() {
+		metaSchemas = make(map[string]interface{})
+		for name, file := range map[string]string{
+			"swagger":  "schemas/swagger-2.0.json",
+			"openapi":  "schemas/openapi-3.0.json",
+			"draft-04": "schemas/draft-04.json",
+		} {
+			data, err := metaSchemaFiles.ReadFile(file)
+			if err != nil {
+				metaSchemasErr = err
+				return
+			}
+			var schema interface{}
+			if err := json.Unmarshal(data, &schema); err != nil {
+				metaSchemasErr = fmt.Errorf("failed to parse %s: %w", file, err)
+				return
+			}
+			metaSchemas[name] = schema
+		}
+	})
+	return metaSchemas, metaSchemasErr
+}
+
+// Validate checks the Swagger specification against the Swagger 2.0 meta-schema and semantic rules.
+// It returns ValidationErrors, or nil when the spec is valid.
+func (s *Swagger) Validate() error {
+	return ValidateSpec(s.spec)
+}
+
+// ValidateSpec checks a spec (*SwaggerSpec, swag spec from LoadSwagDocs or raw JSON)
+// against the official Swagger 2.0 or OpenAPI 3.0 JSON meta-schema, then applies semantic rules:
+//   - operationIds are unique
+//   - path template parameters are declared, and declared path parameters are used
+//   - internal $refs resolve
+//   - operations have at most one body parameter
+//
+// All problems are returned together as ValidationErrors, each located by a JSON pointer.
+//
+// Example:
+//
+//	if err := swagger.ValidateSpec(swagSpec); err != nil {
+//	    var problems swagger.ValidationErrors
+//	    errors.As(err, &problems)
+//	    for _, p := range problems {
+//	        log.Printf("%s: %s", p.Pointer, p.Message)
+//	    }
+//	}
+func ValidateSpec(spec interface{}) error {
+	specMap, err := toSpecMap(spec)
+	if err != nil {
+		return err
+	}
+	schemas, err := loadMetaSchemas()
+	if err != nil {
+		return err
+	}
+
+	doc := specMap
+	if host, ok := doc["host"].(string); ok && host == "" {
+		// swag leaves host and basePath empty when they are not annotated;
+		// both are filled in at serve time, so validate the document without them
+		doc = shallowCopy(doc)
+		delete(doc, "host")
+	}
+	if basePath, ok := doc["basePath"].(string); ok && basePath == "" {
+		doc = shallowCopy(doc)
+		delete(doc, "basePath")
+	}
+
+	metaSchema := schemas["swagger"]
+	if isOpenAPI3(doc) {
+		metaSchema = schemas["openapi"]
+	}
+
+	validator := &schemaValidator{documents: map[string]interface{}{
+		"http://json-schema.org/draft-04/schema": schemas["draft-04"],
+	}}
+	var errs []ValidationError
+	validator.validate(metaSchema, metaSchema, doc, "", &errs)
+	errs = append(errs, semanticErrors(doc)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Pointer < errs[j].Pointer })
+	return ValidationErrors(errs)
+}
+
+// isOpenAPI3 reports whether spec is an OpenAPI 3.x document rather than Swagger 2.0
+func isOpenAPI3(spec map[string]interface{}) bool {
+	version, _ := spec["openapi"].(string)
+	return strings.HasPrefix(version, "3.")
+}
+
+// shallowCopy copies the top level of a map
+func shallowCopy(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// semanticErrors applies the rules the meta-schema cannot express
+func semanticErrors(spec map[string]interface{}) []ValidationError {
+	var errs []ValidationError
+	operationIDs := make(map[string]string)
+
+	for _, op := range specOperations(spec) {
+		if id, ok := op.Operation["operationId"].(string); ok && id != "" {
+			if first, exists := operationIDs[id]; exists {
+				errs = append(errs, ValidationError{
+					Pointer: op.Pointer + "/operationId",
+					Message: fmt.Sprintf("duplicate operationId %q, first used at %s", id, first),
+				})
+			} else {
+				operationIDs[id] = op.Pointer + "/operationId"
+			}
+		}
+
+		declared := make(map[string]bool)
+		bodyCount := 0
+		for _, param := range op.parameters(spec) {
+			switch param["in"] {
+			case "path":
+				name, _ := param["name"].(string)
+				declared[name] = true
+				if !strings.Contains(op.Path, "{"+name+"}") {
+					errs = append(errs, ValidationError{
+						Pointer: op.Pointer,
+						Message: fmt.Sprintf("path parameter %q is declared but not used in %s", name, op.Path),
+					})
+				}
+			case "body":
+				bodyCount++
+			}
+		}
+		if bodyCount > 1 {
+			errs = append(errs, ValidationError{
+				Pointer: op.Pointer + "/parameters",
+				Message: fmt.Sprintf("operation has %d body parameters, at most one is allowed", bodyCount),
+			})
+		}
+		for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+			if !declared[match[1]] {
+				errs = append(errs, ValidationError{
+					Pointer: op.Pointer,
+					Message: fmt.Sprintf("path parameter %q is used in %s but not declared", match[1], op.Path),
+				})
+			}
+		}
+	}
+
+	return append(errs, danglingRefs(spec, spec, "")...)
+}
+
+// effectiveParameters merges path-level and operation-level parameters, resolving internal refs.
+// Operation parameters override path parameters with the same name and location.
+func effectiveParameters(spec map[string]interface{}, pathParams, opParams []interface{}) []map[string]interface{} {
+	var params []map[string]interface{}
+	index := make(map[string]int)
+	for _, list := range [][]interface{}{pathParams, opParams} {
+		for _, raw := range list {
+			param, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if ref, ok := param["$ref"].(string); ok {
+				resolved, err := ResolveRef(spec, ref)
+				if err != nil {
+					continue
+				}
+				if param, ok = resolved.(map[string]interface{}); !ok {
+					continue
+				}
+			}
+			key := fmt.Sprint(param["in"], ":", param["name"])
+			if i, exists := index[key]; exists {
+				params[i] = param
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// danglingRefs reports internal $refs inside value that do not resolve
+func danglingRefs(spec map[string]interface{}, value interface{}, pointer string) []ValidationError {
+	var errs []ValidationError
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			if _, err := ResolveRef(spec, ref); err != nil {
+				errs = append(errs, ValidationError{Pointer: pointer + "/$ref", Message: err.Error()})
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			errs = append(errs, danglingRefs(spec, v[key], pointer+"/"+escapePointerToken(key))...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			errs = append(errs, danglingRefs(spec, item, fmt.Sprintf("%s/%d", pointer, i))...)
+		}
+	}
+	return errs
+}
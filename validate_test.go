@@ -0,0 +1,130 @@
+package swagger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validSwagSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Users API", "version": "1.0"},
+	"host": "",
+	"basePath": "/api",
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"operationId": "getUser",
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+				"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/User"}}}
+			}
+		}
+	},
+	"definitions": {"User": {"type": "object", "properties": {"id": {"type": "integer"}}}}
+}`
+
+func validationProblems(t *testing.T, err error) ValidationErrors {
+	t.Helper()
+	var problems ValidationErrors
+	require.True(t, errors.As(err, &problems), "expected ValidationErrors, got %v", err)
+	return problems
+}
+
+func TestValidateSpec(t *testing.T) {
+	t.Run("valid swag spec", func(t *testing.T) {
+		assert.NoError(t, ValidateSpec(validSwagSpec))
+	})
+
+	t.Run("valid typed spec", func(t *testing.T) {
+		s := New(NewConfig().WithBearerAuth(true).WithContact("API Team", "api@example.com", "https://example.com"))
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("meta-schema violations", func(t *testing.T) {
+		spec := `{
+			"swagger": "2.0",
+			"info": {"title": "API"},
+			"paths": {"/users": {"get": {"responses": {"20x": {"description": "bad code"}}}}}
+		}`
+
+		problems := validationProblems(t, ValidateSpec(spec))
+
+		assert.Contains(t, problems, ValidationError{Pointer: "/info", Message: `missing required property "version"`})
+		assert.Contains(t, problems, ValidationError{Pointer: "/paths/~1users/get/responses/20x", Message: `property "20x" is not allowed`})
+	})
+
+	t.Run("semantic rules", func(t *testing.T) {
+		spec := `{
+			"swagger": "2.0",
+			"info": {"title": "API", "version": "1.0"},
+			"paths": {
+				"/users/{id}": {
+					"get": {"operationId": "getUser", "responses": {"200": {"description": "OK"}}},
+					"post": {
+						"operationId": "getUser",
+						"parameters": [
+							{"name": "a", "in": "body", "schema": {"$ref": "#/definitions/Missing"}},
+							{"name": "b", "in": "body", "schema": {"type": "object"}},
+							{"name": "org", "in": "path", "required": true, "type": "string"}
+						],
+						"responses": {"200": {"description": "OK"}}
+					}
+				}
+			},
+			"definitions": {}
+		}`
+
+		problems := validationProblems(t, ValidateSpec(spec))
+
+		assert.Contains(t, problems, ValidationError{
+			Pointer: "/paths/~1users~1{id}/get",
+			Message: `path parameter "id" is used in /users/{id} but not declared`,
+		})
+		assert.Contains(t, problems, ValidationError{
+			Pointer: "/paths/~1users~1{id}/post/operationId",
+			Message: `duplicate operationId "getUser", first used at /paths/~1users~1{id}/get/operationId`,
+		})
+		assert.Contains(t, problems, ValidationError{
+			Pointer: "/paths/~1users~1{id}/post",
+			Message: `path parameter "org" is declared but not used in /users/{id}`,
+		})
+		assert.Contains(t, problems, ValidationError{
+			Pointer: "/paths/~1users~1{id}/post/parameters",
+			Message: "operation has 2 body parameters, at most one is allowed",
+		})
+		assert.Contains(t, problems, ValidationError{
+			Pointer: "/paths/~1users~1{id}/post/parameters/0/schema/$ref",
+			Message: `unresolvable $ref "#/definitions/Missing": "Missing" not found`,
+		})
+	})
+
+	t.Run("openapi 3 document", func(t *testing.T) {
+		spec := `{
+			"openapi": "3.0.3",
+			"info": {"title": "API", "version": "1.0"},
+			"paths": {"/ping": {"get": {"responses": {"200": {"description": "OK"}}}}}
+		}`
+		assert.NoError(t, ValidateSpec(spec))
+
+		problems := validationProblems(t, ValidateSpec(`{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"}}`))
+		assert.Contains(t, problems, ValidationError{Pointer: "", Message: `missing required property "paths"`})
+	})
+}
+
+func TestSetupWithSwagFailOnInvalidSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	spec, err := LoadSwagDocs(`{"swagger": "2.0", "paths": {}}`)
+	require.NoError(t, err)
+
+	config := DefaultConfig().WithFailOnInvalidSpec(true)
+	err = SetupWithSwag(gin.New(), spec, config)
+	assert.ErrorContains(t, err, `missing required property "info"`)
+
+	valid, err := LoadSwagDocs(validSwagSpec)
+	require.NoError(t, err)
+	assert.NoError(t, SetupWithSwag(gin.New(), valid, config))
+}
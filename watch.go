@@ -0,0 +1,271 @@
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultWatchInterval is how often a SpecWatcher polls its file
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// SpecWatcher serves a swagger.json or swagger.yaml from disk and swaps in a new copy
+// whenever the file changes, so `swag init` shows up without restarting the server.
+// The file is polled, which works the same on every OS, in containers and on network mounts.
+type SpecWatcher struct {
+	path     string
+	interval time.Duration
+	logger   *log.Logger
+
+	// spec holds the current map[string]interface{}; version counts successful loads
+	spec    atomic.Value
+	version atomic.Int64
+
+	mu          sync.Mutex
+	modTime     time.Time
+	size        int64
+	sum         [sha256.Size]byte
+	lastErr     error
+	subscribers map[chan int64]struct{}
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewSpecWatcher loads the spec at path. It fails when the file cannot be read, parsed or validated;
+// later reloads that fail keep serving the last good spec.
+//
+// Example:
+//
+//	watcher, err := swagger.NewSpecWatcher("docs/swagger.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	watcher.Start(ctx)
+//	defer watcher.Stop()
+//
+//	swagger.SetupWithWatcher(router, watcher, config)
+func NewSpecWatcher(path string) (*SpecWatcher, error) {
+	w := &SpecWatcher{
+		path:        path,
+		interval:    DefaultWatchInterval,
+		logger:      log.Default(),
+		subscribers: make(map[chan int64]struct{}),
+		stop:        make(chan struct{}),
+	}
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WithInterval sets how often the file is checked for changes; DefaultWatchInterval when not positive
+func (w *SpecWatcher) WithInterval(interval time.Duration) *SpecWatcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	w.interval = interval
+	return w
+}
+
+// WithLogger sets the logger receiving reloads and reload errors
+func (w *SpecWatcher) WithLogger(logger *log.Logger) *SpecWatcher {
+	w.logger = logger
+	return w
+}
+
+// Start keeps checking the file in the background until Stop is called or ctx is done
+func (w *SpecWatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.Reload()
+			}
+		}
+	}()
+}
+
+// Stop ends background checking started by Start
+func (w *SpecWatcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// Reload loads the file if it changed since the last successful load, validates it
+// and swaps it in, notifying the Events subscribers. changed is false when the content
+// is the same; on errors the previous spec stays in place.
+func (w *SpecWatcher) Reload() (changed bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, w.failed(err)
+	}
+	if w.lastErr == nil && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return false, w.failed(err)
+	}
+	sum := sha256.Sum256(data)
+	if w.lastErr == nil && sum == w.sum && w.spec.Load() != nil {
+		// touched but not changed
+		w.modTime, w.size = info.ModTime(), info.Size()
+		return false, nil
+	}
+
+	spec, err := decodeWatchedSpec(w.path, data)
+	if err != nil {
+		return false, w.failed(err)
+	}
+	if err := ValidateSpec(spec); err != nil {
+		return false, w.failed(fmt.Errorf("%s: invalid spec: %w", w.path, err))
+	}
+
+	initial := w.spec.Load() == nil
+	w.spec.Store(spec)
+	w.modTime, w.size, w.sum, w.lastErr = info.ModTime(), info.Size(), sum, nil
+	version := w.version.Add(1)
+	if !initial {
+		w.logger.Printf("[SWAGGER] reloaded %s", w.path)
+		for subscriber := range w.subscribers {
+			select {
+			case subscriber <- version:
+			default:
+				// the subscriber has not caught up with the previous reload yet
+			}
+		}
+	}
+	return true, nil
+}
+
+// failed records a reload error, logging it once until it changes
+func (w *SpecWatcher) failed(err error) error {
+	if w.lastErr == nil || w.lastErr.Error() != err.Error() {
+		w.logger.Printf("[SWAGGER] keeping the previous spec: %v", err)
+	}
+	w.lastErr = err
+	return err
+}
+
+// decodeWatchedSpec parses swag output: JSON through LoadSwagDocs, YAML by extension
+func decodeWatchedSpec(path string, data []byte) (map[string]interface{}, error) {
+	var spec interface{}
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		spec, err = decodeDocument(path, data)
+	default:
+		spec, err = LoadSwagDocs(string(bytes.TrimSpace(data)))
+		if err != nil {
+			err = fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	specMap, ok := spec.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: swagger spec must be an object", path)
+	}
+	return specMap, nil
+}
+
+// Spec returns the current spec. It is shared; use cloneValue before mutating.
+func (w *SpecWatcher) Spec() map[string]interface{} {
+	return w.spec.Load().(map[string]interface{})
+}
+
+// Err returns the error of the last reload, or nil when the served spec is up to date
+func (w *SpecWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastErr
+}
+
+// Subscribe registers a channel receiving the version of every reload; call the returned
+// function to unsubscribe. Events covers net/http; adapters that stream natively use this.
+func (w *SpecWatcher) Subscribe() (<-chan int64, func()) {
+	ch := make(chan int64, 1)
+	w.mu.Lock()
+	w.subscribers[ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		delete(w.subscribers, ch)
+		w.mu.Unlock()
+	}
+}
+
+// Events returns a handler streaming a server-sent "reload" event, carrying the spec version,
+// after every reload. Handler serves it at UIPath/events for the Swagger UI page.
+func (w *SpecWatcher) Events() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			http.Error(rw, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		updates, unsubscribe := w.Subscribe()
+		defer unsubscribe()
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.Header().Set("Connection", "keep-alive")
+		rw.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case version := <-updates:
+				fmt.Fprintf(rw, "event:reload\ndata:%d\n\n", version)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
+
+// SetupWithWatcher configures Swagger UI routes serving the spec of a SpecWatcher.
+// Open Swagger UI pages subscribe to UIPath/events and fetch the spec again after every reload.
+//
+// Example (development only):
+//
+//	if os.Getenv("SWAGGER_ENV") == "development" {
+//	    watcher, err := swagger.NewSpecWatcher("docs/swagger.json")
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    watcher.Start(ctx)
+//	    err = swagger.SetupWithWatcher(router, watcher, config)
+//	}
+func SetupWithWatcher(router *gin.Engine, watcher *SpecWatcher, config *Config) error {
+	handler, err := NewHandler(watcher, config)
+	if err != nil {
+		return err
+	}
+	return SetupHandler(router, handler)
+}
@@ -0,0 +1,164 @@
+package swagger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchedSpec returns a minimal valid spec documenting a single path
+func watchedSpec(path string) string {
+	return `{"swagger": "2.0", "info": {"title": "API", "version": "1.0"}, "paths": {"` + path + `": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+}
+
+// writeWatched writes content with a distinct modification time so polling notices it
+func writeWatched(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func newTestWatcher(t *testing.T, name, content string) (*SpecWatcher, string, *bytes.Buffer) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	writeWatched(t, path, content, time.Hour)
+
+	watcher, err := NewSpecWatcher(path)
+	require.NoError(t, err)
+	var logs bytes.Buffer
+	watcher.WithLogger(log.New(&logs, "", 0))
+	return watcher, path, &logs
+}
+
+func TestSpecWatcherReload(t *testing.T) {
+	watcher, path, logs := newTestWatcher(t, "swagger.json", watchedSpec("/users"))
+	assert.Contains(t, watcher.Spec()["paths"], "/users")
+
+	changed, err := watcher.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed, "the file did not change")
+
+	writeWatched(t, path, watchedSpec("/users"), 50*time.Minute)
+	changed, err = watcher.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed, "touched with the same content")
+
+	writeWatched(t, path, `{"swagger": "2.0", "paths": {`, 40*time.Minute)
+	_, err = watcher.Reload()
+	require.Error(t, err)
+	assert.Contains(t, watcher.Spec()["paths"], "/users", "the previous spec is kept on parse errors")
+	assert.Equal(t, err, watcher.Err())
+
+	_, err = watcher.Reload()
+	require.Error(t, err)
+	assert.Equal(t, 1, strings.Count(logs.String(), "keeping the previous spec"), "the same error is logged once")
+
+	writeWatched(t, path, `{"swagger": "2.0", "paths": {}}`, 30*time.Minute)
+	_, err = watcher.Reload()
+	assert.ErrorContains(t, err, "invalid spec")
+	assert.Contains(t, watcher.Spec()["paths"], "/users", "invalid specs are not served")
+
+	writeWatched(t, path, watchedSpec("/orders"), 20*time.Minute)
+	changed, err = watcher.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, watcher.Spec()["paths"], "/orders")
+	assert.NoError(t, watcher.Err())
+	assert.Contains(t, logs.String(), "[SWAGGER] reloaded "+path)
+}
+
+func TestSpecWatcherYAML(t *testing.T) {
+	watcher, path, _ := newTestWatcher(t, "swagger.yaml", "swagger: '2.0'\ninfo: {title: API, version: '1.0'}\npaths:\n  /users:\n    get:\n      responses:\n        '200': {description: OK}\n")
+	assert.Contains(t, watcher.Spec()["paths"], "/users")
+
+	watcher.WithInterval(10 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.Start(ctx)
+	defer watcher.Stop()
+
+	writeWatched(t, path, "swagger: '2.0'\ninfo: {title: API, version: '1.0'}\npaths:\n  /orders:\n    get:\n      responses:\n        '200': {description: OK}\n", time.Minute)
+	assert.Eventually(t, func() bool {
+		_, ok := watcher.Spec()["paths"].(map[string]interface{})["/orders"]
+		return ok
+	}, time.Second, 10*time.Millisecond, "polling picks up the change")
+}
+
+func TestSpecWatcherInvalidInterval(t *testing.T) {
+	watcher, _, _ := newTestWatcher(t, "swagger.json", `{"swagger": "2.0", "info": {"title": "API", "version": "1.0"}, "paths": {}}`)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		watcher.WithInterval(interval)
+		assert.Equal(t, DefaultWatchInterval, watcher.interval)
+	}
+
+	// a non-positive ticker interval would panic in the polling goroutine
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.Start(ctx)
+	watcher.Stop()
+}
+
+func TestNewSpecWatcherErrors(t *testing.T) {
+	_, err := NewSpecWatcher(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "swagger.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0o644))
+	_, err = NewSpecWatcher(path)
+	assert.EqualError(t, err, path+": swagger spec must be an object")
+}
+
+func TestSetupWithWatcher(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	watcher, path, _ := newTestWatcher(t, "swagger.json", watchedSpec("/users"))
+
+	router := gin.New()
+	require.NoError(t, SetupWithWatcher(router, watcher, quietConfig()))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	get := func(path string) string {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	assert.Contains(t, get("/swagger/index.html"), `new EventSource("/swagger/events")`)
+	assert.Contains(t, get("/swagger.json"), `"/users"`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/swagger/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// the handler has subscribed once the headers are flushed
+	writeWatched(t, path, watchedSpec("/orders"), time.Minute)
+	_, err = watcher.Reload()
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(resp.Body)
+	event, _ := reader.ReadString('\n')
+	data, _ := reader.ReadString('\n')
+	assert.Equal(t, "event:reload\n", event)
+	assert.Equal(t, "data:2\n", data)
+
+	assert.Contains(t, get("/swagger.json"), `"/orders"`)
+}